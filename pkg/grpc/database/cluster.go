@@ -0,0 +1,346 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+)
+
+var (
+	lockingReadRegex  = regexp.MustCompile(`(?i)\bFOR\s+(UPDATE|NO\s+KEY\s+UPDATE|SHARE|KEY\s+SHARE)\b`) // row locking reads
+	selectIntoRegex   = regexp.MustCompile(`(?i)\bINTO\b`)                                               // SELECT ... INTO new_table
+	functionCallRegex = regexp.MustCompile(`([A-Za-z_][\w$.]*)\s*\(`)                                    // name( calls & keywords
+)
+
+// replicaSafeCalls are the SQL keywords that can precede a parenthesis and the built-in functions without side effects
+// that may be called by a query routed to a replica. Any other call (i.e. nextval() or a user function) goes to the primary.
+var replicaSafeCalls = map[string]bool{
+	"select": true, "from": true, "where": true, "and": true, "or": true, "not": true, "in": true, "exists": true,
+	"any": true, "all": true, "some": true, "values": true, "on": true, "join": true, "as": true, "using": true,
+	"over": true, "filter": true, "within": true, "union": true, "intersect": true, "except": true, "case": true,
+	"when": true, "then": true, "else": true, "by": true, "having": true, "lateral": true, "is": true, "like": true,
+	"ilike": true, "between": true, "distinct": true, "limit": true, "offset": true, "row": true, "array": true,
+	"count": true, "sum": true, "avg": true, "min": true, "max": true, "coalesce": true, "nullif": true,
+	"ifnull": true, "greatest": true, "least": true, "cast": true, "extract": true, "lower": true, "upper": true,
+	"length": true, "char_length": true, "substr": true, "substring": true, "trim": true, "ltrim": true, "rtrim": true,
+	"concat": true, "replace": true, "position": true, "strpos": true, "split_part": true, "abs": true, "round": true,
+	"floor": true, "ceil": true, "date_trunc": true, "to_char": true, "array_agg": true, "string_agg": true,
+	"group_concat": true, "json_agg": true, "jsonb_agg": true, "unnest": true, "row_number": true, "rank": true,
+	"dense_rank": true,
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context routing every cluster query run with it to the primary,
+// i.e. to read back rows just written (read-your-writes) regardless of the replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// usePrimary reports if the context requires the queries to run on the primary.
+func usePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// ClusterConfig describes a primary database and its read replicas.
+type ClusterConfig struct {
+	Primary             DSN           // Database receiving writes & transactions
+	Replicas            []DSN         // Read replicas receiving read-only queries
+	Pool                *PoolConfig   // Connection pool settings for every database (default: DefaultPoolConfig)
+	HealthCheckInterval time.Duration // How often the replicas are pinged (default: 10s)
+	HealthCheckTimeout  time.Duration // Maximum time to wait for a replica ping (default: 2s)
+}
+
+// Cluster routes read-only queries to healthy read replicas (round-robin) and everything else to the primary.
+type Cluster struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	next     atomic.Uint64
+	config   ClusterConfig
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     sync.WaitGroup
+}
+
+// replica is a read replica of the cluster, along with its last known health.
+type replica struct {
+	db      *sqlx.DB
+	name    string
+	healthy atomic.Bool
+}
+
+// OpenCluster opens the primary & replica databases, checks the replicas health and keeps checking it in the background.
+// The cluster must be closed with Close.
+func OpenCluster(config ClusterConfig) (*Cluster, error) {
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if config.HealthCheckTimeout <= 0 {
+		config.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+	primary, err := OpenDBConnectionDSN(config.Primary)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cluster{primary: primary, config: config, stop: make(chan struct{})}
+	c.applyPoolConfig(primary)
+	for _, dsn := range config.Replicas {
+		db, openErr := OpenDBConnectionDSN(dsn)
+		if openErr != nil {
+			c.closeDBs()
+			return nil, openErr
+		}
+		c.applyPoolConfig(db)
+		c.replicas = append(c.replicas, &replica{db: db, name: dsn.Redacted()})
+	}
+	if len(c.replicas) > 0 {
+		c.checkReplicas(context.Background())
+		c.done.Add(1)
+		go c.healthCheckLoop()
+	}
+	return c, nil
+}
+
+// applyPoolConfig applies the cluster (or default) pool settings to the given DB.
+func (c *Cluster) applyPoolConfig(db *sqlx.DB) {
+	choosePoolConfig(c.config.Pool).apply(db)
+}
+
+// Primary returns the primary database, to be used for writes & transactions.
+func (c *Cluster) Primary() *sqlx.DB {
+	return c.primary
+}
+
+// Replica returns the next healthy read replica (round-robin), or the primary if none are healthy.
+func (c *Cluster) Replica() *sqlx.DB {
+	if r := c.nextReplica(); r != nil {
+		return r.db
+	}
+	return c.primary
+}
+
+// nextReplica returns the next healthy replica, or nil if there are none.
+func (c *Cluster) nextReplica() *replica {
+	count := uint64(len(c.replicas))
+	for i := uint64(0); i < count; i++ {
+		r := c.replicas[(c.next.Add(1)-1)%count]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// WithTx runs the given function in a transaction on the primary database (see WithTx).
+func (c *Cluster) WithTx(ctx context.Context, opts *TxOptions, fn func(tx *TxQueryContext) error) error {
+	return WithTx(ctx, c.primary, opts, fn)
+}
+
+// Close stops the health checks and closes all the cluster databases.
+func (c *Cluster) Close() {
+	if c == nil {
+		return
+	}
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.done.Wait()
+		c.closeDBs()
+	})
+}
+
+// closeDBs closes the primary & replica databases.
+func (c *Cluster) closeDBs() {
+	CloseDBConnection(c.primary)
+	for _, r := range c.replicas {
+		CloseDBConnection(r.db)
+	}
+}
+
+// healthCheckLoop pings the replicas periodically until the cluster is closed.
+func (c *Cluster) healthCheckLoop() {
+	defer c.done.Done()
+	ticker := time.NewTicker(c.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.checkReplicas(context.Background())
+		}
+	}
+}
+
+// checkReplicas pings each replica, updating its health.
+func (c *Cluster) checkReplicas(ctx context.Context) {
+	for _, r := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, c.config.HealthCheckTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+		c.setHealth(r, err)
+	}
+}
+
+// setHealth records the health of the replica, logging any change.
+func (c *Cluster) setHealth(r *replica, err error) {
+	healthy := err == nil
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		zlog.S.Infof("Database replica %s is healthy", r.name)
+	} else {
+		zlog.S.Warnf("Database replica %s is unhealthy. Routing its reads to the primary: %v", r.name, err)
+	}
+}
+
+// NewClusterQueryContext creates a DBQueryContext routing read-only queries to the cluster replicas
+// and everything else to the primary.
+func NewClusterQueryContext(s *zap.SugaredLogger, cluster *Cluster, trace bool) *DBQueryContext {
+	return &DBQueryContext{s: s, db: cluster.Primary(), cluster: cluster, trace: trace}
+}
+
+// isReadOnlyQuery reports if the query can be run on a replica: a SELECT without row locking,
+// INTO or calls to functions which might have side effects.
+func isReadOnlyQuery(query string) bool {
+	if sqlOperation(query) != "SELECT" {
+		return false
+	}
+	query = stringLiteralRegex.ReplaceAllString(query, "''")
+	if lockingReadRegex.MatchString(query) || selectIntoRegex.MatchString(query) {
+		return false
+	}
+	for _, match := range functionCallRegex.FindAllStringSubmatch(query, -1) {
+		if !replicaSafeCalls[strings.ToLower(match[1])] {
+			return false
+		}
+	}
+	return true
+}
+
+// isConnectionError reports if the error was caused by the connection to the database, rather than by the query.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr)
+}
+
+// clusterRunner runs read-only queries on a healthy replica, failing over to the primary, and everything else on the primary.
+type clusterRunner struct {
+	c *Cluster
+}
+
+// replicaFor returns the replica to run the given query on, or nil if it should go to the primary.
+func (r clusterRunner) replicaFor(ctx context.Context, query string) *replica {
+	if usePrimary(ctx) || !isReadOnlyQuery(query) {
+		return nil
+	}
+	return r.c.nextReplica()
+}
+
+// failover reports if a failed replica query should be retried on the primary, marking the replica as unhealthy.
+func (r clusterRunner) failover(ctx context.Context, rep *replica, err error) bool {
+	if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+		return false
+	}
+	r.c.setHealth(rep, fmt.Errorf("query failed: %v", err))
+	return true
+}
+
+func (r clusterRunner) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		err := rep.db.SelectContext(ctx, dest, query, args...)
+		if !r.failover(ctx, rep, err) {
+			return err
+		}
+		resetSlice(dest)
+	}
+	return r.c.primary.SelectContext(ctx, dest, query, args...)
+}
+
+func (r clusterRunner) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		err := rep.db.GetContext(ctx, dest, query, args...)
+		if !r.failover(ctx, rep, err) {
+			return err
+		}
+	}
+	return r.c.primary.GetContext(ctx, dest, query, args...)
+}
+
+func (r clusterRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.c.primary.ExecContext(ctx, query, args...)
+}
+
+func (r clusterRunner) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		rows, err := rep.db.QueryxContext(ctx, query, args...)
+		if !r.failover(ctx, rep, err) {
+			return rows, err
+		}
+	}
+	return r.c.primary.QueryxContext(ctx, query, args...)
+}
+
+func (r clusterRunner) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		row := rep.db.QueryRowxContext(ctx, query, args...)
+		if !r.failover(ctx, rep, row.Err()) {
+			return row
+		}
+	}
+	return r.c.primary.QueryRowxContext(ctx, query, args...)
+}
+
+func (r clusterRunner) Rebind(query string) string {
+	return r.c.primary.Rebind(query)
+}
+
+// resetSlice empties the slice pointed to by dest, discarding any rows loaded before a failure.
+func resetSlice(dest interface{}) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Slice {
+		v.Elem().SetLen(0)
+	}
+}
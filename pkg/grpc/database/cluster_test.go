@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// createClusterDB creates a SQLite database file holding a single person with the given name.
+func createClusterDB(t *testing.T, name string) DSN {
+	dsn := DSN{Driver: "sqlite", Database: filepath.Join(t.TempDir(), name+".db")}
+	db, err := OpenDBConnectionDSN(dsn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.MustExec("CREATE TABLE person (name text)")
+	db.MustExec("INSERT INTO person (name) VALUES ($1)", name)
+	return dsn
+}
+
+func TestIsReadOnlyQuery(t *testing.T) {
+	tests := map[string]bool{
+		"SELECT name FROM person":                                   true,
+		"  select name from person where name = $1":                 true,
+		"SELECT name FROM person FOR UPDATE":                        false,
+		"SELECT name FROM person FOR NO KEY UPDATE":                 false,
+		"select name from person for share":                         false,
+		"INSERT INTO person (name) VALUES ($1)":                     false,
+		"WITH d AS (DELETE FROM person) SELECT 1":                   false,
+		"UPDATE person SET name = 'for update'":                     false,
+		"SELECT name FROM person WHERE note = 'for update'":         true,
+		"SELECT count(*), max(id) FROM person WHERE id IN ($1, $2)": true,
+		"SELECT name FROM person WHERE note = 'nextval(x)'":         true,
+		"SELECT nextval('person_id_seq')":                           false,
+		"SELECT pg_advisory_lock(1)":                                false,
+		"SELECT name FROM person WHERE id = audit.log_access($1)":   false,
+		"SELECT name INTO backup FROM person":                       false,
+	}
+	for query, want := range tests {
+		if got := isReadOnlyQuery(query); got != want {
+			t.Errorf("isReadOnlyQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestClusterRouting(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cluster, err := OpenCluster(ClusterConfig{
+		Primary:  createClusterDB(t, "primary"),
+		Replicas: []DSN{createClusterDB(t, "replica1"), createClusterDB(t, "replica2")},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer cluster.Close()
+	ctx := context.Background()
+	q := NewClusterQueryContext(zlog.S, cluster, true)
+	var names []string
+	for i := 0; i < 4; i++ {
+		var name string
+		if err = q.GetContext(ctx, &name, "SELECT name FROM person"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		names = append(names, name)
+	}
+	if names[0] == names[1] || names[0] != names[2] || names[1] != names[3] || names[0] == "primary" || names[1] == "primary" {
+		t.Errorf("Expected reads to alternate between the replicas, got %v", names)
+	}
+	if _, err = q.ExecContext(ctx, "INSERT INTO person (name) VALUES ($1)", "written"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var written string
+	if err = q.GetContext(WithPrimary(ctx), &written, "SELECT name FROM person WHERE name = $1", "written"); err != nil {
+		t.Errorf("Expected to read the write back from the primary: %v", err)
+	}
+	var count int
+	if err = cluster.Primary().Get(&count, "SELECT count(*) FROM person"); err != nil || count != 2 {
+		t.Errorf("Expected the write on the primary, got %v people (%v)", count, err)
+	}
+	err = cluster.WithTx(ctx, nil, func(tx *TxQueryContext) error {
+		return tx.GetContext(ctx, &count, "SELECT count(*) FROM person")
+	})
+	if err != nil || count != 2 {
+		t.Errorf("Expected the transaction on the primary, got %v people (%v)", count, err)
+	}
+	cluster.Close() // closing twice should be safe
+}
+
+func TestClusterFailover(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cluster, err := OpenCluster(ClusterConfig{
+		Primary:            createClusterDB(t, "primary"),
+		Replicas:           []DSN{{Driver: "postgres", User: "user", Password: "passwd", Host: "127.0.0.1:1", Database: "kb", SSLMode: "disable"}},
+		HealthCheckTimeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer cluster.Close()
+	if cluster.replicas[0].healthy.Load() {
+		t.Errorf("Expected the unreachable replica to be unhealthy")
+	}
+	if cluster.Replica() != cluster.Primary() {
+		t.Errorf("Expected the primary to be used with no healthy replicas")
+	}
+	// Pretend the replica is healthy, so that the query fails on it and is retried on the primary
+	cluster.replicas[0].healthy.Store(true)
+	q := NewClusterQueryContext(zlog.S, cluster, false)
+	var names []string
+	if err = q.SelectContext(context.Background(), &names, "SELECT name FROM person"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "primary" {
+		t.Errorf("Expected the read to fail over to the primary, got %v", names)
+	}
+	if cluster.replicas[0].healthy.Load() {
+		t.Errorf("Expected the failed replica to be marked unhealthy")
+	}
+}
+
+func TestClusterHealthCheck(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cluster, err := OpenCluster(ClusterConfig{
+		Primary:             createClusterDB(t, "primary"),
+		Replicas:            []DSN{createClusterDB(t, "replica")},
+		HealthCheckInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer cluster.Close()
+	replicaDB := cluster.replicas[0].db
+	if cluster.Replica() != replicaDB {
+		t.Fatalf("Expected the healthy replica to be used")
+	}
+	_ = replicaDB.Close() // the background check should notice the replica has gone
+	deadline := time.Now().Add(time.Second)
+	for cluster.replicas[0].healthy.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if cluster.Replica() != cluster.Primary() {
+		t.Errorf("Expected reads to move to the primary once the replica is unhealthy")
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// CredentialSource describes where the database password is read from.
+// The first source set is used: a literal Password, an environment variable or a file (i.e. a mounted Kubernetes secret).
+type CredentialSource struct {
+	Password        string        // Literal password
+	EnvVar          string        // Environment variable holding the password
+	File            string        // File holding the password (surrounding whitespace is trimmed)
+	RefreshInterval time.Duration // Re-read the password after this interval (0: only on authentication failures)
+}
+
+// read loads the password from the source.
+func (s CredentialSource) read() (string, error) {
+	switch {
+	case len(s.Password) > 0:
+		return s.Password, nil
+	case len(s.EnvVar) > 0:
+		password, ok := os.LookupEnv(s.EnvVar)
+		if !ok {
+			return "", fmt.Errorf("database password environment variable %s is not set", s.EnvVar)
+		}
+		return password, nil
+	case len(s.File) > 0:
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			zlog.S.Errorf("Failed to read database password file %s: %v", s.File, err)
+			return "", fmt.Errorf("failed to read database password file: %v", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", nil
+}
+
+// credentials caches the password of a CredentialSource, re-reading it when it is stale or rejected.
+type credentials struct {
+	source   CredentialSource
+	now      func() time.Time
+	lock     sync.Mutex
+	password string
+	loaded   time.Time
+}
+
+// newCredentials creates a password cache for the given source.
+func newCredentials(source CredentialSource) *credentials {
+	return &credentials{source: source, now: time.Now}
+}
+
+// get returns the cached password, re-reading it first if it was never loaded or the refresh interval has elapsed.
+func (c *credentials) get() (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.loaded.IsZero() && (c.source.RefreshInterval <= 0 || c.now().Sub(c.loaded) < c.source.RefreshInterval) {
+		return c.password, nil
+	}
+	return c.load()
+}
+
+// reload re-reads the password, reporting if it changed.
+func (c *credentials) reload() (string, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	previous := c.password
+	password, err := c.load()
+	return password, err == nil && password != previous, err
+}
+
+// load reads the password from the source. The lock must be held.
+func (c *credentials) load() (string, error) {
+	password, err := c.source.read()
+	if err != nil {
+		return "", err
+	}
+	c.password, c.loaded = password, c.now()
+	return password, nil
+}
+
+// rotatingConnector opens connections with the current password of its credentials, so that new
+// connections pick up a rotated password. If the database rejects the password, it is re-read and,
+// if it changed, the connection is retried.
+type rotatingConnector struct {
+	driver driver.Driver
+	dsn    DSN
+	creds  *credentials
+}
+
+// Connect opens a new connection using the current password.
+func (c *rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.creds.get()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.connect(ctx, password)
+	if err == nil || classifyPingError(err) != PingErrorAuth {
+		return conn, err
+	}
+	zlog.S.Warnf("Database %s rejected the credentials. Reloading them: %v", c.dsn.Redacted(), err)
+	password, changed, reloadErr := c.creds.reload()
+	if reloadErr != nil || !changed {
+		return nil, err
+	}
+	return c.connect(ctx, password)
+}
+
+// connect opens a connection to the DSN with the given password.
+func (c *rotatingConnector) connect(ctx context.Context, password string) (driver.Conn, error) {
+	dsn := c.dsn
+	dsn.Password = password
+	if driverCtx, ok := c.driver.(driver.DriverContext); ok {
+		connector, err := driverCtx.OpenConnector(dsn.Build())
+		if err != nil {
+			return nil, err
+		}
+		return connector.Connect(ctx)
+	}
+	return c.driver.Open(dsn.Build())
+}
+
+// Driver returns the wrapped database driver.
+func (c *rotatingConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// lookupDriver returns the registered database driver with the given name (i.e. postgres or sqlite).
+func lookupDriver(name string) (driver.Driver, error) {
+	db, err := sql.Open(name, "")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return db.Driver(), nil
+}
+
+// OpenDBConnectionWithCredentials establishes a connection to the database described by the given DSN,
+// taking the password from the given source instead of the DSN. New connections use the current password,
+// so a rotated secret is picked up without restarting the service (limit ConnMaxLifetime to recycle the old ones).
+func OpenDBConnectionWithCredentials(dsn DSN, source CredentialSource) (*sqlx.DB, error) {
+	zlog.S.Debugf("Connecting to Database %s...", dsn.Redacted())
+	drv, err := lookupDriver(dsn.Driver)
+	if err != nil {
+		zlog.S.Errorf("Failed to open database %s: %v", dsn.Redacted(), err)
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	creds := newCredentials(source)
+	if _, err = creds.get(); err != nil {
+		zlog.S.Errorf("Failed to load database credentials: %v", err)
+		return nil, fmt.Errorf("failed to load database credentials: %v", err)
+	}
+	db := sqlx.NewDb(sql.OpenDB(&rotatingConnector{driver: drv, dsn: dsn, creds: creds}), dsn.Driver)
+	registerDBStats(db, dsn.poolName())
+	return db, nil
+}
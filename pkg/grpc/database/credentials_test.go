@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// credTestDriver is a fake driver only accepting connections with its current password.
+type credTestDriver struct {
+	lock     sync.Mutex
+	password string
+	attempts int
+}
+
+func (d *credTestDriver) Open(name string) (driver.Conn, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.attempts++
+	u, err := url.Parse(name)
+	if err != nil {
+		return nil, err
+	}
+	if password, _ := u.User.Password(); password != d.password {
+		return nil, &sqlStateError{code: "28P01"}
+	}
+	return credTestConn{}, nil
+}
+
+// rotate changes the accepted password, returning the number of connection attempts so far.
+func (d *credTestDriver) rotate(password string) int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.password = password
+	return d.attempts
+}
+
+// credTestConn is a connection of the credTestDriver.
+type credTestConn struct{}
+
+func (credTestConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+
+func (credTestConn) Close() error { return nil }
+
+func (credTestConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+var credDriver = &credTestDriver{}
+
+func init() {
+	sql.Register("credtest", credDriver)
+}
+
+func TestCredentialSourceRead(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	filename := filepath.Join(t.TempDir(), "password")
+	if err = os.WriteFile(filename, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Setenv("DB_TEST_PASSWORD", "env-secret")
+	tests := []struct {
+		name    string
+		source  CredentialSource
+		want    string
+		wantErr bool
+	}{
+		{name: "literal", source: CredentialSource{Password: "literal-secret", File: filename}, want: "literal-secret"},
+		{name: "env", source: CredentialSource{EnvVar: "DB_TEST_PASSWORD"}, want: "env-secret"},
+		{name: "env missing", source: CredentialSource{EnvVar: "DB_TEST_PASSWORD_MISSING"}, wantErr: true},
+		{name: "file", source: CredentialSource{File: filename}, want: "file-secret"},
+		{name: "file missing", source: CredentialSource{File: filename + ".missing"}, wantErr: true},
+		{name: "none", source: CredentialSource{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.read()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected password %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCredentialsRefresh(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(filename, []byte("one"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	now := time.Now()
+	creds := newCredentials(CredentialSource{File: filename, RefreshInterval: time.Minute})
+	creds.now = func() time.Time { return now }
+	if password, err := creds.get(); err != nil || password != "one" {
+		t.Fatalf("Expected password one, got %v (%v)", password, err)
+	}
+	if err := os.WriteFile(filename, []byte("two"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if password, _ := creds.get(); password != "one" {
+		t.Errorf("Expected the cached password before the refresh interval, got %v", password)
+	}
+	now = now.Add(time.Minute)
+	if password, _ := creds.get(); password != "two" {
+		t.Errorf("Expected the rotated password after the refresh interval, got %v", password)
+	}
+	if _, changed, _ := creds.reload(); changed {
+		t.Errorf("Expected an unchanged password on reload")
+	}
+}
+
+func TestOpenDBConnectionWithCredentialsRotation(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	filename := filepath.Join(t.TempDir(), "password")
+	if err = os.WriteFile(filename, []byte("old-secret\n"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	credDriver.rotate("old-secret")
+	db, err := OpenDBConnectionWithCredentials(DSN{Driver: "credtest", User: "scanoss", Host: "db", Database: "kb"},
+		CredentialSource{File: filename})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.SetMaxIdleConns(0) // force a new connection for every ping
+	ctx := context.Background()
+	if err = db.PingContext(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Rotate the secret on the server, but not (yet) in the file
+	attempts := credDriver.rotate("new-secret")
+	err = db.PingContext(ctx)
+	if !IsAuthError(err) {
+		t.Errorf("Expected an authentication error, got %v", err)
+	}
+	if got := credDriver.rotate("new-secret") - attempts; got != 1 {
+		t.Errorf("Expected no retry with an unchanged password, got %v attempts", got)
+	}
+	// Update the mounted secret, which should be picked up after the auth failure
+	if err = os.WriteFile(filename, []byte("new-secret\n"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err = db.PingContext(ctx); err != nil {
+		t.Errorf("Expected the rotated password to be used, got %v", err)
+	}
+	if err = db.PingContext(ctx); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestOpenDBConnectionWithCredentialsSQLite(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnectionWithCredentials(DSN{Driver: "sqlite", Database: filepath.Join(t.TempDir(), "kb.db")}, CredentialSource{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	db.MustExec("INSERT INTO person (firstname, lastname) VALUES ('harry', 'potter')")
+	var count int
+	if err = db.Get(&count, "SELECT count(*) FROM person"); err != nil || count != 1 {
+		t.Errorf("Expected 1 person, got %v (%v)", count, err)
+	}
+	if _, err = OpenDBConnectionWithCredentials(DSN{Driver: "does-not-exist"}, CredentialSource{}); err == nil {
+		t.Errorf("Expected an error for an unknown driver")
+	}
+	if _, err = OpenDBConnectionWithCredentials(DSN{Driver: "sqlite"}, CredentialSource{EnvVar: "DB_TEST_PASSWORD_MISSING"}); err == nil {
+		t.Errorf("Expected an error for missing credentials")
+	}
+}
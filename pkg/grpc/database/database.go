@@ -24,8 +24,8 @@
 package database
 
 import (
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/jmoiron/sqlx"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
@@ -34,31 +34,44 @@ import (
 var defaultLike = "LIKE"
 
 // OpenDBConnection establishes a connection specified database.
+// If no dsn is supplied, one is built from the remaining parameters (see DSN).
+// If telemetry is enabled (see SetupTelemetry), the connection pool statistics are reported as metrics.
 func OpenDBConnection(dsn, driver, user, passwd, host, schema, sslMode string) (*sqlx.DB, error) {
 	if len(dsn) == 0 {
-		dsn = fmt.Sprintf("%s://%s:%s@%s/%s?sslmode=%s",
-			driver,
-			user,
-			passwd,
-			host,
-			schema,
-			sslMode)
+		return OpenDBConnectionDSN(DSN{Driver: driver, User: user, Password: passwd, Host: host, Database: schema, SSLMode: sslMode})
 	}
-	zlog.S.Debug("Connecting to Database...")
+	return openDB(driver, dsn, RedactDSN(dsn), driver)
+}
+
+// OpenDBConnectionDSN establishes a connection to the database described by the given DSN.
+func OpenDBConnectionDSN(dsn DSN) (*sqlx.DB, error) {
+	return openDB(dsn.Driver, dsn.Build(), dsn.Redacted(), dsn.poolName())
+}
+
+// openDB opens the given connection string, only ever logging its redacted form.
+func openDB(driver, dsn, redactedDSN, poolName string) (*sqlx.DB, error) {
+	zlog.S.Debugf("Connecting to Database %s...", redactedDSN)
 	db, err := sqlx.Open(driver, dsn)
 	if err != nil {
-		zlog.S.Errorf("Failed to open database: %v", err)
+		zlog.S.Errorf("Failed to open database %s: %v", redactedDSN, err)
 		return nil, fmt.Errorf("failed to open database: %v", err)
 	}
+	registerDBStats(db, poolName)
 	return db, nil
 }
 
-// SetDBOptionsAndPing configures DB connections and attempts to ping it.
+// SetDBOptionsAndPing configures DB connections with the default pool settings (DefaultPoolConfig) and attempts to ping it.
 func SetDBOptionsAndPing(db *sqlx.DB) error {
-	db.SetConnMaxIdleTime(30 * time.Minute) // TODO add to app config
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetMaxIdleConns(20)
-	db.SetMaxOpenConns(200)
+	return SetDBOptionsAndPingWithConfig(db, DefaultPoolConfig())
+}
+
+// SetDBOptionsAndPingWithConfig configures DB connections with the given pool settings
+// (i.e. PoolConfigForDriver(db.DriverName()) for the driver's recommended settings) and attempts to ping it.
+func SetDBOptionsAndPingWithConfig(db *sqlx.DB, config PoolConfig) error {
+	if db == nil {
+		return errors.New("no database supplied")
+	}
+	config.apply(db)
 	err := db.Ping()
 	if err != nil {
 		zlog.S.Errorf("Failed to ping database: %v", err)
@@ -70,6 +83,7 @@ func SetDBOptionsAndPing(db *sqlx.DB) error {
 // CloseDBConnection closes specified database connection.
 func CloseDBConnection(db *sqlx.DB) {
 	if db != nil {
+		unregisterDBStats(db)
 		err := db.Close()
 		if err != nil {
 			zlog.S.Warnf("Problem closing DB: %v", err)
@@ -87,22 +101,7 @@ func CloseSQLConnection(conn *sqlx.Conn) {
 	}
 }
 
-// GetLikeOperator attempts to determine the case-insensitive like operator based on the DB driver.
+// GetLikeOperator attempts to determine the case-insensitive like operator based on the DB driver (see Dialect).
 func GetLikeOperator(db *sqlx.DB) string {
-	if db != nil {
-		driverName := db.DriverName()
-		switch driverName {
-		case "postgres":
-			return "ILIKE"
-		case "sqlite3":
-			return defaultLike
-		case "sqlite":
-			return defaultLike
-		}
-		zlog.S.Warnf("DriverName %s is unknown. Defaulting to %s", driverName, defaultLike)
-	} else {
-		zlog.S.Warnf("No DB object supplied. Defaulting to %s.", defaultLike)
-	}
-	// Return the default like operator
-	return defaultLike
+	return DialectFor(db).LikeOperator()
 }
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// Dialect hides the SQL differences between the supported databases (Postgres & SQLite),
+// so that the same queries can be run in production and in tests.
+type Dialect interface {
+	// Name returns the name of the dialect (i.e. postgres or sqlite).
+	Name() string
+	// LikeOperator returns the case-insensitive like operator.
+	LikeOperator() string
+	// Placeholder returns the bind parameter for the n-th (1-based) argument of a query.
+	Placeholder(n int) string
+	// Rebind converts a query written with '?' bind parameters to the dialect's parameter style.
+	Rebind(query string) string
+	// In expands slice arguments of a query written with '?' parameters (i.e. "id IN (?)") and rebinds it.
+	In(query string, args ...interface{}) (string, []interface{}, error)
+	// Upsert returns an insert statement for the given columns, updating updateColumns if a row with
+	// the same conflictColumns already exists (or ignoring the insert if no update columns are supplied).
+	Upsert(table string, columns, conflictColumns, updateColumns []string) string
+	// LimitOffset returns the clause limiting the rows returned by a query. A limit <= 0 means no limit.
+	LimitOffset(limit, offset int) string
+	// BoolLiteral returns the SQL literal for the given boolean.
+	BoolLiteral(b bool) string
+	// Now returns the SQL expression for the current timestamp.
+	Now() string
+}
+
+// DialectFor returns the SQL dialect of the given DB, based on its driver name.
+// It defaults to SQLite if the driver is unknown.
+func DialectFor(db *sqlx.DB) Dialect {
+	if db == nil {
+		zlog.S.Warnf("No DB object supplied. Defaulting to the sqlite dialect.")
+		return sqliteDialect{}
+	}
+	return DialectForDriver(db.DriverName())
+}
+
+// DialectForDriver returns the SQL dialect of the given driver name. It defaults to SQLite if the driver is unknown.
+func DialectForDriver(driver string) Dialect {
+	switch driver {
+	case "postgres", "pgx":
+		return postgresDialect{}
+	case "sqlite", "sqlite3":
+		return sqliteDialect{}
+	}
+	zlog.S.Warnf("DriverName %s is unknown. Defaulting to the sqlite dialect", driver)
+	return sqliteDialect{}
+}
+
+// postgresDialect is the Postgres SQL dialect.
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) LikeOperator() string { return "ILIKE" }
+
+func (postgresDialect) Placeholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+func (postgresDialect) Rebind(query string) string { return sqlx.Rebind(sqlx.DOLLAR, query) }
+
+func (d postgresDialect) In(query string, args ...interface{}) (string, []interface{}, error) {
+	return expandIn(d, query, args...)
+}
+
+func (d postgresDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return upsertStatement(d, table, columns, conflictColumns, updateColumns)
+}
+
+func (postgresDialect) LimitOffset(limit, offset int) string {
+	var clauses []string
+	if limit > 0 {
+		clauses = append(clauses, fmt.Sprintf("LIMIT %d", limit))
+	}
+	if offset > 0 {
+		clauses = append(clauses, fmt.Sprintf("OFFSET %d", offset))
+	}
+	return strings.Join(clauses, " ")
+}
+
+func (postgresDialect) BoolLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (postgresDialect) Now() string { return "now()" }
+
+// sqliteDialect is the SQLite SQL dialect.
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+// LikeOperator returns LIKE, which is case-insensitive (for ASCII characters) in SQLite.
+func (sqliteDialect) LikeOperator() string { return defaultLike }
+
+func (sqliteDialect) Placeholder(int) string { return "?" }
+
+func (sqliteDialect) Rebind(query string) string { return query }
+
+func (d sqliteDialect) In(query string, args ...interface{}) (string, []interface{}, error) {
+	return expandIn(d, query, args...)
+}
+
+func (d sqliteDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return upsertStatement(d, table, columns, conflictColumns, updateColumns)
+}
+
+// LimitOffset returns the limit clause. SQLite requires a LIMIT (-1 for none) when an OFFSET is supplied.
+func (sqliteDialect) LimitOffset(limit, offset int) string {
+	if limit <= 0 && offset <= 0 {
+		return ""
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	if offset > 0 {
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (sqliteDialect) BoolLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (sqliteDialect) Now() string { return "CURRENT_TIMESTAMP" }
+
+// expandIn expands the slice arguments of the query and rebinds it to the dialect's parameter style.
+func expandIn(d Dialect, query string, args ...interface{}) (string, []interface{}, error) {
+	expanded, expandedArgs, err := sqlx.In(query, args...)
+	if err != nil {
+		zlog.S.Errorf("Failed to expand query arguments: %v", err)
+		return "", nil, fmt.Errorf("failed to expand query arguments: %v", err)
+	}
+	return d.Rebind(expanded), expandedArgs, nil
+}
+
+// upsertStatement builds an INSERT ... ON CONFLICT statement (supported by both Postgres & SQLite 3.24+).
+func upsertStatement(d Dialect, table string, columns, conflictColumns, updateColumns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = d.Placeholder(i + 1)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) ",
+		table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(conflictColumns, ", "))
+	if len(updateColumns) == 0 {
+		sb.WriteString("DO NOTHING")
+		return sb.String()
+	}
+	updates := make([]string, len(updateColumns))
+	for i, column := range updateColumns {
+		updates[i] = fmt.Sprintf("%s = excluded.%s", column, column)
+	}
+	sb.WriteString("DO UPDATE SET " + strings.Join(updates, ", "))
+	return sb.String()
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// postgresTestDSN is the environment variable pointing the dialect suite at a Postgres test database.
+const postgresTestDSN = "DB_TEST_POSTGRES_DSN"
+
+func TestDialectForDriver(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := map[string]string{"postgres": "postgres", "pgx": "postgres", "sqlite": "sqlite", "sqlite3": "sqlite", "unknown": "sqlite"}
+	for driver, want := range tests {
+		if got := DialectForDriver(driver).Name(); got != want {
+			t.Errorf("Expected %v dialect for %v, got %v", want, driver, got)
+		}
+	}
+	if got := DialectFor(nil).Name(); got != "sqlite" {
+		t.Errorf("Expected sqlite dialect for a nil DB, got %v", got)
+	}
+}
+
+func TestDialectSQL(t *testing.T) {
+	tests := []struct {
+		dialect     Dialect
+		like        string
+		rebind      string
+		upsert      string
+		ignore      string
+		limitOffset []string
+		boolean     string
+		now         string
+	}{
+		{
+			dialect:     postgresDialect{},
+			like:        "ILIKE",
+			rebind:      "SELECT * FROM t WHERE a = $1 AND b = $2",
+			upsert:      "INSERT INTO t (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name",
+			ignore:      "INSERT INTO t (id, name) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING",
+			limitOffset: []string{"", "LIMIT 10", "LIMIT 10 OFFSET 20", "OFFSET 20"},
+			boolean:     "TRUE",
+			now:         "now()",
+		},
+		{
+			dialect:     sqliteDialect{},
+			like:        "LIKE",
+			rebind:      "SELECT * FROM t WHERE a = ? AND b = ?",
+			upsert:      "INSERT INTO t (id, name) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name",
+			ignore:      "INSERT INTO t (id, name) VALUES (?, ?) ON CONFLICT (id) DO NOTHING",
+			limitOffset: []string{"", "LIMIT 10", "LIMIT 10 OFFSET 20", "LIMIT -1 OFFSET 20"},
+			boolean:     "1",
+			now:         "CURRENT_TIMESTAMP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect.Name(), func(t *testing.T) {
+			d := tt.dialect
+			if got := d.LikeOperator(); got != tt.like {
+				t.Errorf("Expected like operator %v, got %v", tt.like, got)
+			}
+			if got := d.Rebind("SELECT * FROM t WHERE a = ? AND b = ?"); got != tt.rebind {
+				t.Errorf("Expected rebind %v, got %v", tt.rebind, got)
+			}
+			if got := d.Upsert("t", []string{"id", "name"}, []string{"id"}, []string{"name"}); got != tt.upsert {
+				t.Errorf("Expected upsert %v, got %v", tt.upsert, got)
+			}
+			if got := d.Upsert("t", []string{"id", "name"}, []string{"id"}, nil); got != tt.ignore {
+				t.Errorf("Expected upsert %v, got %v", tt.ignore, got)
+			}
+			limits := []string{d.LimitOffset(0, 0), d.LimitOffset(10, 0), d.LimitOffset(10, 20), d.LimitOffset(0, 20)}
+			if !reflect.DeepEqual(limits, tt.limitOffset) {
+				t.Errorf("Expected limit/offset clauses %q, got %q", tt.limitOffset, limits)
+			}
+			if got := d.BoolLiteral(true); got != tt.boolean {
+				t.Errorf("Expected boolean literal %v, got %v", tt.boolean, got)
+			}
+			if got := d.Now(); got != tt.now {
+				t.Errorf("Expected now %v, got %v", tt.now, got)
+			}
+			query, args, err := d.In("SELECT * FROM t WHERE a = ? AND id IN (?)", "x", []int{1, 2, 3})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if want := d.Rebind("SELECT * FROM t WHERE a = ? AND id IN (?, ?, ?)"); query != want || len(args) != 4 {
+				t.Errorf("Expected expanded query %v with 4 args, got %v %v", want, query, args)
+			}
+		})
+	}
+}
+
+// TestDialectQueries runs the same queries against SQLite and, if DB_TEST_POSTGRES_DSN is set, Postgres.
+func TestDialectQueries(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	drivers := map[string]string{"sqlite": ":memory:", "postgres": os.Getenv(postgresTestDSN)}
+	for driver, dsn := range drivers {
+		t.Run(driver, func(t *testing.T) {
+			if len(dsn) == 0 {
+				t.Skipf("%s not set", postgresTestDSN)
+			}
+			db, err := OpenDBConnection(dsn, driver, "", "", "", "", "")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer CloseDBConnection(db)
+			db.SetMaxOpenConns(1)
+			testDialectQueries(t, db)
+		})
+	}
+}
+
+// testDialectQueries exercises each dialect feature against the given database.
+func testDialectQueries(t *testing.T, db *sqlx.DB) {
+	ctx := context.Background()
+	q := NewDBSelectContext(zlog.S, db, nil, false)
+	d := q.Dialect()
+	db.MustExec("DROP TABLE IF EXISTS dialect_person")
+	db.MustExec("CREATE TABLE dialect_person (id INTEGER PRIMARY KEY, name TEXT NOT NULL, active BOOLEAN NOT NULL, updated TIMESTAMP)")
+	defer db.MustExec("DROP TABLE IF EXISTS dialect_person")
+
+	upsert := d.Upsert("dialect_person", []string{"id", "name", "active"}, []string{"id"}, []string{"name", "active"})
+	for _, p := range []struct {
+		id     int
+		name   string
+		active bool
+	}{{1, "Harry", true}, {2, "Ron", true}, {3, "Hermione", true}, {2, "Ronald", false}} {
+		if _, err := q.ExecContext(ctx, upsert, p.id, p.name, p.active); err != nil {
+			t.Fatalf("Unexpected upsert error: %v", err)
+		}
+	}
+	ignore := d.Upsert("dialect_person", []string{"id", "name", "active"}, []string{"id"}, nil)
+	if _, err := q.ExecContext(ctx, ignore, 1, "Voldemort", false); err != nil {
+		t.Fatalf("Unexpected upsert error: %v", err)
+	}
+	var names []string
+	if err := q.SelectContext(ctx, &names, "SELECT name FROM dialect_person ORDER BY id"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"Harry", "Ronald", "Hermione"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v after upserts, got %v", want, names)
+	}
+
+	var name string
+	if err := q.GetContext(ctx, &name, d.Rebind("SELECT name FROM dialect_person WHERE name "+d.LikeOperator()+" ?"), "hERm%"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Hermione" {
+		t.Errorf("Expected case-insensitive match of Hermione, got %v", name)
+	}
+
+	query, args, err := d.In("SELECT name FROM dialect_person WHERE active = ? AND id IN (?) ORDER BY id", true, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	names = nil
+	if err = q.SelectContext(ctx, &names, query, args...); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"Harry", "Hermione"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v from IN query, got %v", want, names)
+	}
+
+	var count int
+	if err = q.GetContext(ctx, &count, "SELECT count(*) FROM dialect_person WHERE active = "+d.BoolLiteral(false)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 inactive person, got %v", count)
+	}
+
+	for limit, want := range map[[2]int][]string{{1, 1}: {"Ronald"}, {0, 2}: {"Hermione"}, {2, 0}: {"Harry", "Ronald"}} {
+		names = nil
+		if err = q.SelectContext(ctx, &names, "SELECT name FROM dialect_person ORDER BY id "+d.LimitOffset(limit[0], limit[1])); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("Expected %v with limit/offset %v, got %v", want, limit, names)
+		}
+	}
+
+	result, err := q.ExecContext(ctx, "UPDATE dialect_person SET updated = "+d.Now()+" WHERE id = "+d.Placeholder(1), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rows, _ := result.RowsAffected(); rows != 1 {
+		t.Errorf("Expected 1 row updated, got %v", rows)
+	}
+	if err = q.GetContext(ctx, &count, "SELECT count(*) FROM dialect_person WHERE updated IS NOT NULL"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 updated timestamp, got %v", count)
+	}
+}
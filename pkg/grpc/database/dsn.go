@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "xxxxx" // replacement for secrets in logged DSNs
+
+var (
+	dsnPathEscaper      = strings.NewReplacer("%", "%25", "?", "%3F", "#", "%23")
+	keyValueSecretRegex = regexp.MustCompile(`(?i)(\b\w*password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`) // key=value DSN secrets
+)
+
+// DSN describes a database connection, which is rendered in the format required by its driver.
+// Credentials are URL-escaped, so they may contain any character (i.e. '@', '/' or '%').
+// Printing a DSN (i.e. with %v) produces its redacted form; use Build to get the connection string.
+type DSN struct {
+	Driver   string     // Driver name (i.e. postgres or sqlite)
+	User     string     // User to connect as
+	Password string     // Password of the user
+	Host     string     // Host (and optional port) to connect to
+	Database string     // Database name, or file path (or :memory:) for SQLite
+	SSLMode  string     // SSL mode (i.e. disable, require, verify-full)
+	Params   url.Values // Extra connection parameters (i.e. connect_timeout, application_name, sslrootcert, _pragma)
+}
+
+// Build returns the connection string for the DSN driver.
+func (d DSN) Build() string {
+	return d.build(false)
+}
+
+// Redacted returns the connection string with any secrets masked, for use in logs and error messages.
+func (d DSN) Redacted() string {
+	return d.build(true)
+}
+
+// String returns the redacted connection string.
+func (d DSN) String() string {
+	return d.Redacted()
+}
+
+// GoString returns the redacted connection string, so that %#v cannot leak secrets either.
+func (d DSN) GoString() string {
+	return d.Redacted()
+}
+
+// poolName returns the name reported in the connection pool metrics.
+func (d DSN) poolName() string {
+	if len(d.Host) > 0 {
+		return fmt.Sprintf("%s/%s", d.Host, d.Database)
+	}
+	return d.Database
+}
+
+// build renders the connection string, optionally masking secrets.
+func (d DSN) build(redact bool) string {
+	params := d.params(redact)
+	switch d.Driver {
+	case "sqlite", "sqlite3":
+		if len(params) == 0 {
+			return d.Database
+		}
+		return "file:" + dsnPathEscaper.Replace(d.Database) + "?" + params.Encode()
+	}
+	scheme := d.Driver
+	if len(scheme) == 0 || scheme == "pgx" {
+		scheme = "postgres"
+	}
+	u := url.URL{Scheme: scheme, Host: d.Host, Path: "/" + d.Database, RawQuery: params.Encode()}
+	switch {
+	case len(d.Password) > 0 && redact:
+		u.User = url.UserPassword(d.User, redacted)
+	case len(d.Password) > 0:
+		u.User = url.UserPassword(d.User, d.Password)
+	case len(d.User) > 0:
+		u.User = url.User(d.User)
+	}
+	return u.String()
+}
+
+// params returns the connection parameters, including the SSL mode, optionally masking secrets.
+func (d DSN) params(redact bool) url.Values {
+	params := url.Values{}
+	for key, values := range d.Params {
+		for _, value := range values {
+			if redact && isSecretParam(key) {
+				value = redacted
+			}
+			params.Add(key, value)
+		}
+	}
+	if len(d.SSLMode) > 0 {
+		params.Set("sslmode", d.SSLMode)
+	}
+	return params
+}
+
+// isSecretParam reports if the given connection parameter holds a secret (i.e. password, sslpassword or passfile).
+func isSecretParam(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "pass") || strings.Contains(key, "secret")
+}
+
+// RedactDSN masks the secrets of the given connection string (URL or key=value format) for logging.
+func RedactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return redacted
+		}
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		query := u.Query()
+		for key := range query {
+			if isSecretParam(key) {
+				query.Set(key, redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+	return keyValueSecretRegex.ReplaceAllString(dsn, "${1}"+redacted)
+}
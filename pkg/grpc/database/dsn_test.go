@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestDSNBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      DSN
+		want     string
+		redacted string
+	}{
+		{
+			name:     "postgres escaped credentials",
+			dsn:      DSN{Driver: "postgres", User: "scanoss", Password: "p@ss/w%rd:", Host: "db:5432", Database: "kb", SSLMode: "disable"},
+			want:     "postgres://scanoss:p%40ss%2Fw%25rd%3A@db:5432/kb?sslmode=disable",
+			redacted: "postgres://scanoss:xxxxx@db:5432/kb?sslmode=disable",
+		},
+		{
+			name: "postgres extra params",
+			dsn: DSN{Driver: "postgres", User: "scanoss", Host: "db", Database: "kb", SSLMode: "verify-full",
+				Params: url.Values{"connect_timeout": {"5"}, "application_name": {"api"}, "sslrootcert": {"/certs/ca.pem"}, "sslpassword": {"secret"}}},
+			want:     "postgres://scanoss@db/kb?application_name=api&connect_timeout=5&sslmode=verify-full&sslpassword=secret&sslrootcert=%2Fcerts%2Fca.pem",
+			redacted: "postgres://scanoss@db/kb?application_name=api&connect_timeout=5&sslmode=verify-full&sslpassword=xxxxx&sslrootcert=%2Fcerts%2Fca.pem",
+		},
+		{
+			name:     "sqlite file",
+			dsn:      DSN{Driver: "sqlite", Database: "/data/kb.db"},
+			want:     "/data/kb.db",
+			redacted: "/data/kb.db",
+		},
+		{
+			name:     "sqlite pragmas",
+			dsn:      DSN{Driver: "sqlite", Database: "/data/kb?1.db", Params: url.Values{"_pragma": {"busy_timeout(5000)", "foreign_keys(1)"}}},
+			want:     "file:/data/kb%3F1.db?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29",
+			redacted: "file:/data/kb%3F1.db?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dsn.Build(); got != tt.want {
+				t.Errorf("Expected DSN %v, got %v", tt.want, got)
+			}
+			if got := tt.dsn.Redacted(); got != tt.redacted {
+				t.Errorf("Expected redacted DSN %v, got %v", tt.redacted, got)
+			}
+			for _, format := range []string{"%v", "%s", "%+v", "%#v"} {
+				if got := fmt.Sprintf(format, tt.dsn); got != tt.redacted {
+					t.Errorf("Expected %s to print the redacted DSN %v, got %v", format, tt.redacted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDSNPostgresParse(t *testing.T) {
+	password := "p@ss/w%rd:?#"
+	dsn := DSN{Driver: "postgres", User: "scanoss", Password: password, Host: "db:5432", Database: "kb", SSLMode: "disable"}
+	parsed, err := pq.ParseURL(dsn.Build())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(parsed, "password='"+strings.ReplaceAll(password, `'`, `\'`)+"'") {
+		t.Errorf("Expected the password to survive parsing, got %v", parsed)
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	tests := map[string]string{
+		"postgres://scanoss:p%40ss@db:5432/kb?sslmode=disable":   "postgres://scanoss:xxxxx@db:5432/kb?sslmode=disable",
+		"postgres://scanoss@db/kb?password=secret":               "postgres://scanoss@db/kb?password=xxxxx",
+		"host=db user=scanoss password=secret dbname=kb":         "host=db user=scanoss password=xxxxx dbname=kb",
+		"host=db password='sec ret' sslpassword=other dbname=kb": "host=db password=xxxxx sslpassword=xxxxx dbname=kb",
+		"file:kb.db?_pragma=busy_timeout(5000)":                  "file:kb.db?_pragma=busy_timeout(5000)",
+	}
+	for dsn, want := range tests {
+		if got := RedactDSN(dsn); got != want {
+			t.Errorf("RedactDSN(%q) = %q, want %q", dsn, got, want)
+		}
+	}
+}
+
+func TestOpenDBConnectionDSN(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	filename := filepath.Join(t.TempDir(), "kb.db")
+	db, err := OpenDBConnectionDSN(DSN{Driver: "sqlite", Database: filename, Params: url.Values{"_pragma": {"busy_timeout(5000)"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	var timeout int
+	if err = db.Get(&timeout, "PRAGMA busy_timeout"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeout != 5000 {
+		t.Errorf("Expected busy timeout 5000, got %v", timeout)
+	}
+	// The legacy parameters should open SQLite files too
+	db2, err := OpenDBConnection("", "sqlite", "", "", "", filename, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db2)
+	if err = db2.Ping(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
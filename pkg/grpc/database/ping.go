@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const (
+	defaultPingAttempts       = 10
+	defaultPingInitialBackoff = 500 * time.Millisecond
+	defaultPingMaxBackoff     = 30 * time.Second
+	authSQLStateClass         = "28" // SQLSTATE class for invalid authorization (i.e. 28P01 invalid password)
+)
+
+// PingErrorKind describes why a database ping failed.
+type PingErrorKind int
+
+const (
+	PingErrorConnectivity PingErrorKind = iota // The database could not be reached (retried)
+	PingErrorAuth                              // The database rejected the credentials (not retried)
+)
+
+// String returns the name of the ping error kind.
+func (k PingErrorKind) String() string {
+	if k == PingErrorAuth {
+		return "authentication"
+	}
+	return "connectivity"
+}
+
+// PingError is returned when the database could not be pinged.
+type PingError struct {
+	Kind     PingErrorKind // Type of failure
+	Attempts int           // Number of pings attempted
+	Err      error         // Last ping error
+}
+
+func (e *PingError) Error() string {
+	return fmt.Sprintf("database %s failure after %d attempt(s): %v", e.Kind, e.Attempts, e.Err)
+}
+
+func (e *PingError) Unwrap() error {
+	return e.Err
+}
+
+// IsAuthError checks if the error is a database authentication failure.
+func IsAuthError(err error) bool {
+	var pingErr *PingError
+	if errors.As(err, &pingErr) {
+		return pingErr.Kind == PingErrorAuth
+	}
+	return classifyPingError(err) == PingErrorAuth
+}
+
+// PingRetryConfig controls how often the database is pinged before giving up.
+// Zero values keep the defaults (10 attempts, 500ms initial backoff, 30s maximum backoff, no jitter).
+type PingRetryConfig struct {
+	Attempts       int           // Maximum number of pings
+	InitialBackoff time.Duration // Wait after the first failed ping, doubled after each further failure
+	MaxBackoff     time.Duration // Maximum wait between pings
+	Jitter         float64       // Random +/- fraction (0-1) applied to each wait, to avoid pods retrying in lockstep
+}
+
+// withDefaults fills in the unset retry settings.
+func (c PingRetryConfig) withDefaults() PingRetryConfig {
+	if c.Attempts <= 0 {
+		c.Attempts = defaultPingAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = defaultPingInitialBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultPingMaxBackoff
+	}
+	c.Jitter = min(max(c.Jitter, 0), 1)
+	return c
+}
+
+// backoff returns the jittered wait before the given (1 based) retry.
+func (c PingRetryConfig) backoff(retry int) time.Duration {
+	wait := c.InitialBackoff
+	for i := 1; i < retry && wait < c.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, c.MaxBackoff)
+	if c.Jitter > 0 {
+		wait = time.Duration(float64(wait) * (1 + c.Jitter*(2*rand.Float64()-1)))
+	}
+	return wait
+}
+
+// classifyPingError determines if the ping error was caused by the credentials or by connectivity.
+func classifyPingError(err error) PingErrorKind {
+	var sqlState interface{ SQLState() string } // implemented by the lib/pq & pgx errors
+	if errors.As(err, &sqlState) && strings.HasPrefix(sqlState.SQLState(), authSQLStateClass) {
+		return PingErrorAuth
+	}
+	return PingErrorConnectivity
+}
+
+// PingWithRetry pings the database until it responds, backing off exponentially between attempts.
+// Authentication failures are returned straight away. The context deadline limits the overall time spent retrying.
+// Failures are returned as a *PingError.
+func PingWithRetry(ctx context.Context, db *sqlx.DB, retry PingRetryConfig) error {
+	if db == nil {
+		return errors.New("no database supplied")
+	}
+	retry = retry.withDefaults()
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			if attempt > 1 {
+				zlog.S.Infof("Database ping succeeded after %d attempts", attempt)
+			}
+			return nil
+		}
+		kind := classifyPingError(err)
+		if kind == PingErrorAuth {
+			zlog.S.Errorf("Database ping attempt %d/%d failed authentication: %v", attempt, retry.Attempts, err)
+			return &PingError{Kind: kind, Attempts: attempt, Err: err}
+		}
+		if attempt >= retry.Attempts {
+			zlog.S.Errorf("Database ping attempt %d/%d failed, giving up: %v", attempt, retry.Attempts, err)
+			return &PingError{Kind: kind, Attempts: attempt, Err: err}
+		}
+		wait := retry.backoff(attempt)
+		zlog.S.Warnf("Database ping attempt %d/%d failed, retrying in %v: %v", attempt, retry.Attempts, wait, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			zlog.S.Errorf("Database ping deadline reached after %d attempts: %v", attempt, ctx.Err())
+			return &PingError{Kind: kind, Attempts: attempt, Err: errors.Join(ctx.Err(), err)}
+		case <-timer.C:
+		}
+	}
+}
+
+// SetDBOptionsAndPingWithRetry configures DB connections with the given pool settings (nil for DefaultPoolConfig)
+// and pings it using PingWithRetry.
+func SetDBOptionsAndPingWithRetry(ctx context.Context, db *sqlx.DB, retry PingRetryConfig, config *PoolConfig) error {
+	if db == nil {
+		return errors.New("no database supplied")
+	}
+	choosePoolConfig(config).apply(db)
+	return PingWithRetry(ctx, db, retry)
+}
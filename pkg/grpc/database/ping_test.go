@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// sqlStateError mimics the SQLSTATE errors returned by the Postgres drivers.
+type sqlStateError struct {
+	code string
+}
+
+func (e *sqlStateError) Error() string {
+	return "pq: error " + e.code
+}
+
+func (e *sqlStateError) SQLState() string {
+	return e.code
+}
+
+func TestClassifyPingError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want PingErrorKind
+	}{
+		{name: "invalid password", err: &sqlStateError{code: "28P01"}, want: PingErrorAuth},
+		{name: "invalid authorization", err: fmt.Errorf("ping: %w", &sqlStateError{code: "28000"}), want: PingErrorAuth},
+		{name: "too many connections", err: &sqlStateError{code: "53300"}, want: PingErrorConnectivity},
+		{name: "connection refused", err: errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"), want: PingErrorConnectivity},
+	}
+	for _, tt := range tests {
+		if got := classifyPingError(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+		if IsAuthError(tt.err) != (tt.want == PingErrorAuth) {
+			t.Errorf("%s: unexpected IsAuthError result", tt.name)
+		}
+	}
+	if !IsAuthError(&PingError{Kind: PingErrorAuth, Attempts: 1, Err: errors.New("denied")}) {
+		t.Errorf("Expected a PingError auth failure to be reported as such")
+	}
+}
+
+func TestPingRetryBackoff(t *testing.T) {
+	retry := PingRetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}.withDefaults()
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second} {
+		if got := retry.backoff(i + 1); got != want {
+			t.Errorf("Expected backoff %v for retry %d, got %v", want, i+1, got)
+		}
+	}
+	retry.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		if got := retry.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Errorf("Expected a jittered backoff within 50%%, got %v", got)
+		}
+	}
+	if defaults := (PingRetryConfig{Jitter: 3}).withDefaults(); defaults.Attempts != defaultPingAttempts || defaults.Jitter != 1 {
+		t.Errorf("Unexpected defaults: %+v", defaults)
+	}
+}
+
+func TestPingWithRetry(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	retry := PingRetryConfig{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	if err = SetDBOptionsAndPingWithRetry(context.Background(), db, retry, nil); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	pool := SQLitePoolConfig()
+	if err = SetDBOptionsAndPingWithRetry(context.Background(), db, retry, &pool); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if open := db.Stats().MaxOpenConnections; open != 1 {
+		t.Errorf("Expected the supplied pool config to be applied, got %v", open)
+	}
+	CloseDBConnection(db) // Pinging a closed DB always fails
+	err = PingWithRetry(context.Background(), db, retry)
+	var pingErr *PingError
+	if !errors.As(err, &pingErr) || pingErr.Kind != PingErrorConnectivity || pingErr.Attempts != 3 {
+		t.Errorf("Expected a connectivity failure after 3 attempts, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = PingWithRetry(ctx, db, PingRetryConfig{Attempts: 100, InitialBackoff: time.Second})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &pingErr) || pingErr.Attempts != 1 {
+		t.Errorf("Expected the context deadline to stop the retries, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the retries to stop at the context deadline, took %v", elapsed)
+	}
+	if err = PingWithRetry(context.Background(), nil, retry); err == nil {
+		t.Errorf("Expected an error with no database")
+	}
+}
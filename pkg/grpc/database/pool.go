@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// Environment variables read by LoadPoolConfigFromEnv.
+const (
+	EnvMaxOpenConns    = "DB_MAX_OPEN_CONNS"
+	EnvMaxIdleConns    = "DB_MAX_IDLE_CONNS"
+	EnvConnMaxLifetime = "DB_CONN_MAX_LIFETIME"
+	EnvConnMaxIdleTime = "DB_CONN_MAX_IDLE_TIME"
+)
+
+// PoolConfig holds the connection pool settings of a DB.
+type PoolConfig struct {
+	MaxOpenConns    int           // Maximum number of open connections (0 = unlimited)
+	MaxIdleConns    int           // Maximum number of idle connections kept in the pool
+	ConnMaxLifetime time.Duration // Maximum time a connection can be reused (0 = forever)
+	ConnMaxIdleTime time.Duration // Maximum time a connection can sit idle (0 = forever)
+}
+
+// poolConfigFile is the on disk (JSON) representation of a PoolConfig. Durations use Go syntax (i.e. 30m).
+type poolConfigFile struct {
+	MaxOpenConns    *int    `json:"MaxOpenConns"`
+	MaxIdleConns    *int    `json:"MaxIdleConns"`
+	ConnMaxLifetime *string `json:"ConnMaxLifetime"`
+	ConnMaxIdleTime *string `json:"ConnMaxIdleTime"`
+}
+
+// DefaultPoolConfig returns the default pool settings (200 open, 20 idle, 1h lifetime, 30m idle time).
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    200,
+		MaxIdleConns:    20,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 30 * time.Minute,
+	}
+}
+
+// SQLitePoolConfig returns the pool settings for SQLite, which needs a single (writer) connection.
+// The connection never expires, as closing it would lose an in-memory DB.
+func SQLitePoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns: 1,
+		MaxIdleConns: 1,
+	}
+}
+
+// PoolConfigForDriver returns the recommended pool settings for the given DB driver.
+// Pass it to SetDBOptionsAndPingWithConfig to opt in, i.e. SetDBOptionsAndPingWithConfig(db, PoolConfigForDriver(db.DriverName())).
+func PoolConfigForDriver(driver string) PoolConfig {
+	switch driver {
+	case "sqlite", "sqlite3":
+		return SQLitePoolConfig()
+	}
+	return DefaultPoolConfig()
+}
+
+// LoadPoolConfigFromEnv overrides the base pool settings with any DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS,
+// DB_CONN_MAX_LIFETIME & DB_CONN_MAX_IDLE_TIME environment variables.
+func LoadPoolConfigFromEnv(base PoolConfig) (PoolConfig, error) {
+	file := poolConfigFile{}
+	for name, target := range map[string]**int{EnvMaxOpenConns: &file.MaxOpenConns, EnvMaxIdleConns: &file.MaxIdleConns} {
+		if value, ok := os.LookupEnv(name); ok && len(value) > 0 {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				zlog.S.Errorf("Invalid %s value %s: %v", name, value, err)
+				return base, fmt.Errorf("invalid %s value: %v", name, err)
+			}
+			*target = &number
+		}
+	}
+	for name, target := range map[string]**string{EnvConnMaxLifetime: &file.ConnMaxLifetime, EnvConnMaxIdleTime: &file.ConnMaxIdleTime} {
+		if value, ok := os.LookupEnv(name); ok && len(value) > 0 {
+			*target = &value
+		}
+	}
+	return file.merge(base)
+}
+
+// LoadPoolConfigFromFile overrides the base pool settings with those in the given JSON file.
+// i.e. {"MaxOpenConns": 20, "MaxIdleConns": 5, "ConnMaxLifetime": "30m", "ConnMaxIdleTime": "5m"}
+func LoadPoolConfigFromFile(filename string, base PoolConfig) (PoolConfig, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		zlog.S.Errorf("Failed to read pool config %s: %v", filename, err)
+		return base, fmt.Errorf("failed to read pool config: %v", err)
+	}
+	var file poolConfigFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		zlog.S.Errorf("Failed to parse pool config %s: %v", filename, err)
+		return base, fmt.Errorf("failed to parse pool config: %v", err)
+	}
+	return file.merge(base)
+}
+
+// merge applies the set values on top of the base pool settings.
+func (f poolConfigFile) merge(base PoolConfig) (PoolConfig, error) {
+	config := base
+	if f.MaxOpenConns != nil {
+		config.MaxOpenConns = *f.MaxOpenConns
+	}
+	if f.MaxIdleConns != nil {
+		config.MaxIdleConns = *f.MaxIdleConns
+	}
+	for _, d := range []struct {
+		name   string
+		value  *string
+		target *time.Duration
+	}{
+		{name: "ConnMaxLifetime", value: f.ConnMaxLifetime, target: &config.ConnMaxLifetime},
+		{name: "ConnMaxIdleTime", value: f.ConnMaxIdleTime, target: &config.ConnMaxIdleTime},
+	} {
+		if d.value == nil {
+			continue
+		}
+		duration, err := time.ParseDuration(*d.value)
+		if err != nil {
+			zlog.S.Errorf("Invalid %s value %s: %v", d.name, *d.value, err)
+			return base, fmt.Errorf("invalid %s value: %v", d.name, err)
+		}
+		*d.target = duration
+	}
+	if config.MaxOpenConns < 0 || config.MaxIdleConns < 0 {
+		return base, fmt.Errorf("invalid pool size: %d open, %d idle", config.MaxOpenConns, config.MaxIdleConns)
+	}
+	return config, nil
+}
+
+// choosePoolConfig returns the supplied pool settings, or the defaults (DefaultPoolConfig) if none were supplied.
+func choosePoolConfig(config *PoolConfig) PoolConfig {
+	if config != nil {
+		return *config
+	}
+	return DefaultPoolConfig()
+}
+
+// apply sets the pool settings on the given DB.
+func (c PoolConfig) apply(db *sqlx.DB) {
+	zlog.S.Debugf("Setting DB pool options: %+v", c)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetMaxOpenConns(c.MaxOpenConns)
+}
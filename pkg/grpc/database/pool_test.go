@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestPoolConfigForDriver(t *testing.T) {
+	if got := PoolConfigForDriver("postgres"); got != DefaultPoolConfig() {
+		t.Errorf("Expected the default pool config for postgres, got %+v", got)
+	}
+	for _, driver := range []string{"sqlite", "sqlite3"} {
+		if got := PoolConfigForDriver(driver); got.MaxOpenConns != 1 || got.ConnMaxLifetime != 0 {
+			t.Errorf("Expected a single never expiring connection for %s, got %+v", driver, got)
+		}
+	}
+	want := PoolConfig{MaxOpenConns: 200, MaxIdleConns: 20, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: 30 * time.Minute}
+	if got := DefaultPoolConfig(); got != want {
+		t.Errorf("Expected the default pool config %+v, got %+v", want, got)
+	}
+}
+
+func TestLoadPoolConfigFromEnv(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    PoolConfig
+		wantErr bool
+	}{
+		{name: "none", want: DefaultPoolConfig()},
+		{name: "all", env: map[string]string{EnvMaxOpenConns: "10", EnvMaxIdleConns: "2", EnvConnMaxLifetime: "5m", EnvConnMaxIdleTime: "1m"},
+			want: PoolConfig{MaxOpenConns: 10, MaxIdleConns: 2, ConnMaxLifetime: 5 * time.Minute, ConnMaxIdleTime: time.Minute}},
+		{name: "partial", env: map[string]string{EnvMaxOpenConns: "25"},
+			want: PoolConfig{MaxOpenConns: 25, MaxIdleConns: 20, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: 30 * time.Minute}},
+		{name: "invalid number", env: map[string]string{EnvMaxIdleConns: "many"}, want: DefaultPoolConfig(), wantErr: true},
+		{name: "invalid duration", env: map[string]string{EnvConnMaxLifetime: "forever"}, want: DefaultPoolConfig(), wantErr: true},
+		{name: "negative", env: map[string]string{EnvMaxOpenConns: "-1"}, want: DefaultPoolConfig(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EnvMaxOpenConns, EnvMaxIdleConns, EnvConnMaxLifetime, EnvConnMaxIdleTime} {
+				t.Setenv(name, tt.env[name])
+			}
+			got, err := LoadPoolConfigFromEnv(DefaultPoolConfig())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadPoolConfigFromFile(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return filename
+	}
+	valid := write("pool.json", `{"MaxOpenConns": 20, "MaxIdleConns": 5, "ConnMaxLifetime": "30m"}`)
+	got, err := LoadPoolConfigFromFile(valid, DefaultPoolConfig())
+	want := PoolConfig{MaxOpenConns: 20, MaxIdleConns: 5, ConnMaxLifetime: 30 * time.Minute, ConnMaxIdleTime: 30 * time.Minute}
+	if err != nil || got != want {
+		t.Errorf("Expected %+v, got %+v (%v)", want, got, err)
+	}
+	for _, filename := range []string{
+		filepath.Join(dir, "missing.json"),
+		write("invalid.json", `{"MaxOpenConns": "twenty"}`),
+		write("duration.json", `{"ConnMaxIdleTime": "soon"}`),
+	} {
+		if _, err = LoadPoolConfigFromFile(filename, DefaultPoolConfig()); err == nil {
+			t.Errorf("Expected an error loading %v", filename)
+		}
+	}
+}
+
+func TestSetDBOptionsAndPingPoolConfig(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	if err = SetDBOptionsAndPing(db); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if open := db.Stats().MaxOpenConnections; open != DefaultPoolConfig().MaxOpenConns {
+		t.Errorf("Expected the default pool config to be applied, got %v", open)
+	}
+	if err = SetDBOptionsAndPingWithConfig(db, PoolConfigForDriver(db.DriverName())); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if open := db.Stats().MaxOpenConnections; open != 1 {
+		t.Errorf("Expected the SQLite preset to allow a single connection, got %v", open)
+	}
+	if err = SetDBOptionsAndPingWithConfig(db, PoolConfig{MaxOpenConns: 5, MaxIdleConns: 2}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if open := db.Stats().MaxOpenConnections; open != 5 {
+		t.Errorf("Expected the supplied pool config to be applied, got %v", open)
+	}
+	if err = SetDBOptionsAndPing(nil); err == nil {
+		t.Errorf("Expected an error with no database")
+	}
+}
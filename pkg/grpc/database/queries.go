@@ -25,19 +25,25 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 var sqlRegex = regexp.MustCompile(`\$\d+`) // regex to check for SQL parameters
 
 type DBQueryContext struct {
-	db    *sqlx.DB
-	conn  *sqlx.Conn
-	s     *zap.SugaredLogger
-	trace bool
+	db      *sqlx.DB
+	conn    *sqlx.Conn
+	tx      *sqlx.Tx
+	cluster *Cluster
+	s       *zap.SugaredLogger
+	trace   bool
 }
 
 // NewDBSelectContext creates a new instance of the DBQueryContext service.
@@ -46,25 +52,187 @@ func NewDBSelectContext(s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, trac
 }
 
 // SelectContext logs the give query before executing it and the result afterward, if tracing is enabled?
+// The query is abandoned if the context is cancelled or its deadline (i.e. propagated from the gRPC/REST request) expires.
 func (q *DBQueryContext) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	if q.trace {
 		q.SQLQueryTrace(query, args...)
 	}
-	var err error
-	if q.conn != nil {
-		err = q.conn.SelectContext(ctx, dest, query, args...)
-	} else {
-		err = q.db.SelectContext(ctx, dest, query, args...)
+	ctx, done := q.observeQuery(ctx, true, query, args)
+	err := q.runner().SelectContext(ctx, dest, query, args...)
+	done(err, returnedRows(dest))
+	if err != nil {
+		q.contextErrorTrace(ctx)
+	} else if q.trace {
+		q.SQLResultsTrace(dest)
+	}
+	return err
+}
+
+// observeQuery starts the telemetry span & timer of a query.
+// The returned function must be called with the outcome once the query completes.
+// Set explain if the query's connection is free again by then, so the plan of a slow query can be logged.
+func (q *DBQueryContext) observeQuery(ctx context.Context, explain bool, query string, args []interface{}) (context.Context,
+	func(err error, rows ...attribute.KeyValue)) {
+	start := time.Now()
+	ctx, span := q.startQuerySpan(ctx, query)
+	return ctx, func(err error, rows ...attribute.KeyValue) {
+		span.end(ctx, err, rows...)
+		q.slowQueryTrace(ctx, time.Since(start), explain, query, args...)
+	}
+}
+
+// contextErrorTrace logs if a query failed because its context was cancelled or timed out.
+func (q *DBQueryContext) contextErrorTrace(ctx context.Context) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			q.s.Warnf("SQL query abandoned (deadline %v): %v", deadline.Format(time.RFC3339Nano), ctxErr)
+		} else {
+			q.s.Warnf("SQL query abandoned: %v", ctxErr)
+		}
+	}
+}
+
+// GetContext runs the given single row query into dest, logging the query & result if tracing is enabled.
+func (q *DBQueryContext) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if q.trace {
+		q.SQLQueryTrace(query, args...)
 	}
-	if err == nil && q.trace {
+	ctx, done := q.observeQuery(ctx, true, query, args)
+	err := q.runner().GetContext(ctx, dest, query, args...)
+	done(err, returnedRowsKey.Int(1))
+	if err != nil {
+		q.contextErrorTrace(ctx)
+	} else if q.trace {
 		q.SQLResultsTrace(dest)
 	}
 	return err
 }
 
+// ExecContext runs the given statement, logging it & the number of rows affected if tracing is enabled.
+func (q *DBQueryContext) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if q.trace {
+		q.SQLQueryTrace(query, args...)
+	}
+	ctx, done := q.observeQuery(ctx, true, query, args)
+	result, err := q.runner().ExecContext(ctx, query, args...)
+	done(err, rowsAffected(result)...)
+	if err != nil {
+		q.contextErrorTrace(ctx)
+	} else if q.trace {
+		q.SQLRowsAffectedTrace(result)
+	}
+	return result, err
+}
+
+// QueryxContext runs the given query, logging it if tracing is enabled. The caller must close the returned rows.
+// The telemetry span, duration & slow query check only cover the query up to its first response,
+// not the reading of the rows, so no returned row count is recorded.
+func (q *DBQueryContext) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	if q.trace {
+		q.SQLQueryTrace(query, args...)
+	}
+	ctx, done := q.observeQuery(ctx, false, query, args)
+	rows, err := q.runner().QueryxContext(ctx, query, args...)
+	done(err)
+	if err != nil {
+		q.contextErrorTrace(ctx)
+	}
+	return rows, err
+}
+
+// QueryRowxContext runs the given single row query, logging it if tracing is enabled.
+// Any error is deferred until the row is scanned.
+func (q *DBQueryContext) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	if q.trace {
+		q.SQLQueryTrace(query, args...)
+	}
+	ctx, done := q.observeQuery(ctx, false, query, args)
+	row := q.runner().QueryRowxContext(ctx, query, args...)
+	err := row.Err()
+	done(err)
+	if err != nil {
+		q.contextErrorTrace(ctx)
+	}
+	return row
+}
+
+// NamedExecContext runs the given statement with named parameters (i.e. :name) bound from arg (struct or map).
+func (q *DBQueryContext) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	bound, args, err := q.bindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return q.ExecContext(ctx, bound, args...)
+}
+
+// NamedQueryContext runs the given query with named parameters (i.e. :name) bound from arg (struct or map).
+// The caller must close the returned rows. It is observed the same way as QueryxContext.
+func (q *DBQueryContext) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	bound, args, err := q.bindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return q.QueryxContext(ctx, bound, args...)
+}
+
+// Dialect returns the SQL dialect of the database the queries are run against.
+func (q *DBQueryContext) Dialect() Dialect {
+	return DialectForDriver(q.driverName())
+}
+
+// queryRunner is the query API shared by sqlx.DB, sqlx.Conn & sqlx.Tx.
+type queryRunner interface {
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+	Rebind(query string) string
+}
+
+// runner returns the transaction or connection to run queries on, if one was supplied,
+// or the cluster/DB pool otherwise.
+func (q *DBQueryContext) runner() queryRunner {
+	if q.tx != nil {
+		return q.tx
+	}
+	if q.conn != nil {
+		return q.conn
+	}
+	if q.cluster != nil {
+		return clusterRunner{c: q.cluster}
+	}
+	return q.db
+}
+
+// bindNamed converts the named parameters of the query into the driver's bind variables.
+func (q *DBQueryContext) bindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	bound, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		q.s.Errorf("Failed to bind named query parameters: %v", err)
+		return "", nil, fmt.Errorf("failed to bind named query parameters: %v", err)
+	}
+	return q.runner().Rebind(bound), args, nil
+}
+
+// SQLRowsAffectedTrace logs the number of rows affected by a statement if debug is enabled.
+func (q *DBQueryContext) SQLRowsAffectedTrace(result sql.Result) {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		q.s.Debugf("SQL Rows Affected: unknown (%v)", err)
+		return
+	}
+	q.s.Debugf("SQL Rows Affected: %d", rows)
+}
+
 // SQLQueryTrace logs the given SQL query if debug is enabled.
 func (q *DBQueryContext) SQLQueryTrace(query string, args ...interface{}) {
-	q.s.Debugf("SQL Query: "+sqlRegex.ReplaceAllString(query, "%v"), args...)
+	q.s.Debugf("SQL Query: %s", formatSQLQuery(query, args...))
+}
+
+// formatSQLQuery interpolates the given arguments into the parameters (i.e. $1) of the query.
+func formatSQLQuery(query string, args ...interface{}) string {
+	return fmt.Sprintf(sqlRegex.ReplaceAllString(query, "%v"), args...)
 }
 
 // SQLResultsTrace logs the given SQL result if debug is enabled.
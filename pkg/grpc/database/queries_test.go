@@ -26,11 +26,14 @@ package database
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
 
 	_ "github.com/lib/pq"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	_ "modernc.org/sqlite"
 )
 
@@ -74,3 +77,127 @@ func TestQuerySQLite(t *testing.T) {
 	}
 	fmt.Printf("Results2: %v\n", results2)
 }
+
+func TestQueryContextDeadline(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	q := NewDBSelectContext(zlog.S, db, nil, false)
+	var results []Persons
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = q.SelectContext(ctx, &results, "SELECT * FROM person")
+	if err == nil {
+		t.Errorf("Expected an error querying with a cancelled context")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	err = q.SelectContext(ctx, &results, "SELECT * FROM person")
+	if err == nil {
+		t.Errorf("Expected an error querying with an expired deadline")
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	q = NewDBSelectContext(zap.New(core).Sugar(), db, nil, false)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	var count int
+	if err = q.QueryRowxContext(ctx, "SELECT count(*) FROM person").Scan(&count); err == nil {
+		t.Errorf("Expected an error querying a row with a cancelled context")
+	}
+	if logs.FilterMessageSnippet("SQL query abandoned").Len() != 1 {
+		t.Errorf("Expected the abandoned row query to be logged, got %v", logs.All())
+	}
+}
+
+// checkQueryContextAPI runs each of the traced query methods against an empty person table.
+func checkQueryContextAPI(t *testing.T, ctx context.Context, q *DBQueryContext) {
+	result, err := q.ExecContext(ctx, "INSERT INTO person (firstname, lastname) VALUES ($1, $2)", "harry", "potter")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rows, _ := result.RowsAffected(); rows != 1 {
+		t.Errorf("Expected 1 row affected, got %v", rows)
+	}
+	_, err = q.NamedExecContext(ctx, "INSERT INTO person (firstname, lastname) VALUES (:firstname, :lastname)",
+		map[string]interface{}{"firstname": "ron", "lastname": "weasley"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var person Persons
+	if err = q.GetContext(ctx, &person, "SELECT firstname, lastname FROM person WHERE firstname = $1", "ron"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if person.LastName != "weasley" {
+		t.Errorf("Expected weasley, got %v", person.LastName)
+	}
+	var count int
+	if err = q.QueryRowxContext(ctx, "SELECT count(*) FROM person").Scan(&count); err != nil || count != 2 {
+		t.Errorf("Expected 2 people, got %v (%v)", count, err)
+	}
+	rows, err := q.QueryxContext(ctx, "SELECT firstname, lastname FROM person ORDER BY firstname")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for rows.Next() {
+		if err = rows.StructScan(&person); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		names = append(names, person.FirstName)
+	}
+	_ = rows.Close()
+	if len(names) != 2 || names[0] != "harry" {
+		t.Errorf("Expected harry & ron, got %v", names)
+	}
+	rows, err = q.NamedQueryContext(ctx, "SELECT firstname, lastname FROM person WHERE lastname = :lastname", Persons{LastName: "potter"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	found := rows.Next()
+	_ = rows.Close()
+	if !found {
+		t.Errorf("Expected to find the potter row")
+	}
+	if _, err = q.NamedExecContext(ctx, "DELETE FROM person WHERE firstname = :missing", map[string]interface{}{}); err == nil {
+		t.Errorf("Expected an error binding a missing named parameter")
+	}
+	result, err = q.ExecContext(ctx, "DELETE FROM person")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rows, _ := result.RowsAffected(); rows != 2 {
+		t.Errorf("Expected 2 rows affected, got %v", rows)
+	}
+}
+
+func TestQueryContextAPI(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.SetMaxOpenConns(1) // keep a single in-memory DB
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	ctx := context.Background()
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkQueryContextAPI(t, ctx, NewDBSelectContext(zlog.S, db, conn, true))
+	CloseSQLConnection(conn) // release the single connection for the DB pool
+	checkQueryContextAPI(t, ctx, NewDBSelectContext(zlog.S, db, nil, true))
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	requestIDKey          = "x-request-id"  // gRPC metadata key carrying the request ID
+	defaultExplainTimeout = 5 * time.Second // maximum time to spend on the EXPLAIN of a slow query
+)
+
+var slowQueries atomic.Pointer[SlowQueryConfig] // active slow query settings (nil if disabled)
+
+// SlowQueryConfig controls the detection & logging of slow queries.
+type SlowQueryConfig struct {
+	Threshold      time.Duration // Log queries taking longer than this at Warn level (0 disables detection)
+	Explain        bool          // Also log the query plan of slow queries (only intended for dev mode)
+	ExplainTimeout time.Duration // Maximum time to spend running the EXPLAIN (default: 5s)
+}
+
+// SetupSlowQueryLogging enables (or disables, with a zero threshold) the logging of slow queries.
+// Slow queries are logged at Warn level, even if tracing is disabled on the DBQueryContext.
+func SetupSlowQueryLogging(config SlowQueryConfig) {
+	if config.Threshold <= 0 {
+		slowQueries.Store(nil)
+		return
+	}
+	if config.ExplainTimeout <= 0 {
+		config.ExplainTimeout = defaultExplainTimeout
+	}
+	slowQueries.Store(&config)
+}
+
+// slowQueryTrace logs the given query, if it took longer than the slow query threshold.
+// The query plan is only logged if requested (explain) and no transaction is active:
+// the EXPLAIN cannot run in the transaction (a failure would abort it) and the pool may have no spare connection (i.e. SQLite).
+// Callers still holding the query's connection (i.e. open rows) must not request it, for the same reason.
+func (q *DBQueryContext) slowQueryTrace(ctx context.Context, elapsed time.Duration, explain bool, query string, args ...interface{}) {
+	config := slowQueries.Load()
+	if config == nil || elapsed < config.Threshold {
+		return
+	}
+	q.s.Warnf("Slow SQL query (%v, request %s): %s, args: %v", elapsed, requestID(ctx), formatSQLQuery(query, args...), args)
+	if config.Explain {
+		if !explain || q.tx != nil {
+			q.s.Debugf("Skipping the slow SQL query plan, as its connection is in use")
+			return
+		}
+		q.explainTrace(ctx, config.ExplainTimeout, query, args...)
+	}
+}
+
+// explainTrace logs the query plan of the given query.
+func (q *DBQueryContext) explainTrace(ctx context.Context, timeout time.Duration, query string, args ...interface{}) {
+	var runner queryRunner
+	switch {
+	case q.conn != nil:
+		runner = q.conn
+	case q.db != nil:
+		runner = q.db
+	default:
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	prefix := "EXPLAIN "
+	switch q.driverName() {
+	case "sqlite", "sqlite3":
+		prefix = "EXPLAIN QUERY PLAN "
+	}
+	rows, err := runner.QueryxContext(ctx, prefix+query, args...)
+	if err != nil {
+		q.s.Debugf("Failed to explain slow SQL query: %v", err)
+		return
+	}
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		values, scanErr := rows.SliceScan()
+		if scanErr != nil {
+			q.s.Debugf("Failed to read slow SQL query plan: %v", scanErr)
+			return
+		}
+		columns := make([]string, 0, len(values))
+		for _, value := range values {
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			columns = append(columns, fmt.Sprint(value))
+		}
+		plan = append(plan, strings.Join(columns, " "))
+	}
+	q.s.Warnf("Slow SQL query plan (request %s):\n%s", requestID(ctx), strings.Join(plan, "\n"))
+}
+
+// requestID returns the ID of the request the query is run for, from the incoming gRPC metadata or the trace ID.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDKey); len(ids) > 0 && len(ids[0]) > 0 {
+			return ids[0]
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return "unknown"
+}
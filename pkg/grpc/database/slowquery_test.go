@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/metadata"
+	_ "modernc.org/sqlite"
+)
+
+// setupSlowQueryLogging enables slow query logging for the duration of the test.
+func setupSlowQueryLogging(t *testing.T, config SlowQueryConfig) {
+	SetupSlowQueryLogging(config)
+	t.Cleanup(func() {
+		SetupSlowQueryLogging(SlowQueryConfig{})
+	})
+}
+
+func TestRequestID(t *testing.T) {
+	traceID := trace.TraceID{0x01, 0x02, 0x03}
+	spanCtx := trace.ContextWithSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{0x01}}))
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "none", ctx: context.Background(), want: "unknown"},
+		{name: "metadata", ctx: metadata.NewIncomingContext(spanCtx, metadata.Pairs(requestIDKey, "req-123")), want: "req-123"},
+		{name: "trace", ctx: spanCtx, want: traceID.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestID(tt.ctx); got != tt.want {
+				t.Errorf("Expected request ID %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSlowQueryTrace(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	q := NewDBSelectContext(zap.New(core).Sugar(), nil, nil, false)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDKey, "req-123"))
+	query := "SELECT * FROM person WHERE firstname = $1"
+
+	q.slowQueryTrace(ctx, time.Second, true, query, "harry")
+	if logs.Len() != 0 {
+		t.Errorf("Expected no logs with slow query detection disabled, got %v", logs.All())
+	}
+	setupSlowQueryLogging(t, SlowQueryConfig{Threshold: 100 * time.Millisecond, Explain: true})
+	q.slowQueryTrace(ctx, 10*time.Millisecond, true, query, "harry")
+	if logs.Len() != 0 {
+		t.Errorf("Expected no logs for a fast query, got %v", logs.All())
+	}
+	q.slowQueryTrace(ctx, time.Second, true, query, "harry")
+	entries := logs.TakeAll()
+	if len(entries) != 1 {
+		t.Fatalf("Expected a single slow query log, got %v", entries)
+	}
+	if entries[0].Level != zapcore.WarnLevel {
+		t.Errorf("Expected a warning, got %v", entries[0].Level)
+	}
+	for _, want := range []string{"1s", "req-123", "firstname = harry", "[harry]"} {
+		if !strings.Contains(entries[0].Message, want) {
+			t.Errorf("Expected slow query log to contain %q, got %v", want, entries[0].Message)
+		}
+	}
+}
+
+func TestSlowQueryExplainSQLite(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	core, logs := observer.New(zapcore.DebugLevel)
+	setupSlowQueryLogging(t, SlowQueryConfig{Threshold: time.Nanosecond, Explain: true})
+	q := NewDBSelectContext(zap.New(core).Sugar(), db, nil, false)
+	var results []Persons
+	if err = q.SelectContext(context.Background(), &results, "SELECT * FROM person WHERE firstname = $1", "harry"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+	if len(warnings) != 2 {
+		t.Fatalf("Expected slow query & plan warnings, got %v", logs.All())
+	}
+	if !strings.Contains(warnings[1].Message, "SCAN") {
+		t.Errorf("Expected the query plan to be logged, got %v", warnings[1].Message)
+	}
+}
+
+func TestSlowQueryExplainSkipped(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	if err = SetDBOptionsAndPingWithConfig(db, SQLitePoolConfig()); err != nil { // a single connection, held by the tx/rows
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	db.MustExec("INSERT INTO person (firstname, lastname) VALUES ('harry', 'potter')")
+	core, logs := observer.New(zapcore.DebugLevel)
+	s := zap.New(core).Sugar()
+	setupSlowQueryLogging(t, SlowQueryConfig{Threshold: time.Nanosecond, Explain: true})
+	start := time.Now()
+	err = WithTx(context.Background(), db, &TxOptions{Logger: s}, func(tx *TxQueryContext) error {
+		var results []Persons
+		return tx.SelectContext(context.Background(), &results, "SELECT * FROM person")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	q := NewDBSelectContext(s, db, nil, false)
+	rows, err := q.QueryxContext(context.Background(), "SELECT * FROM person")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for rows.Next() {
+	}
+	_ = rows.Close()
+	var count int
+	if err = q.QueryRowxContext(context.Background(), "SELECT count(*) FROM person").Scan(&count); err != nil || count != 1 {
+		t.Errorf("Expected 1 person, got %v (%v)", count, err)
+	}
+	if elapsed := time.Since(start); elapsed > defaultExplainTimeout/2 {
+		t.Errorf("Expected the queries not to wait for an EXPLAIN connection, took %v", elapsed)
+	}
+	if plans := logs.FilterMessageSnippet("query plan (request").Len(); plans != 0 {
+		t.Errorf("Expected no query plans to be logged, got %v", logs.All())
+	}
+	if slow := logs.FilterMessageSnippet("Slow SQL query (").Len(); slow != 3 {
+		t.Errorf("Expected 3 slow queries to be logged, got %v", logs.All())
+	}
+}
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+
+const (
+	returnedRowsKey = attribute.Key("db.response.returned_rows")
+	rowsAffectedKey = attribute.Key("db.rows_affected")
+	poolNameKey     = attribute.Key("db.client.connections.pool.name")
+)
+
+var (
+	stringLiteralRegex  = regexp.MustCompile(`'(?:[^']|'')*'`)               // SQL string literals
+	numericLiteralRegex = regexp.MustCompile(`(^|[^\w$.])-?\d+(?:\.\d+)?\b`) // SQL numeric literals (not $n parameters)
+	operationRegex      = regexp.MustCompile(`^[\s(]*([A-Za-z]+)`)           // leading SQL keyword
+	telemetry           atomic.Pointer[dbTelemetry]                          // active instrumentation (nil if disabled)
+	statsLock           sync.Mutex                                           // guards statsRegistrations
+	statsRegistrations  = map[*sqlx.DB]metric.Registration{}                 // DB stats callbacks by pool
+)
+
+// TelemetryConfig controls the OpenTelemetry spans & metrics produced by the database package.
+type TelemetryConfig struct {
+	Enabled            bool                 // Produce spans & metrics for queries and connection pools
+	SanitizeStatements bool                 // Replace literal values in db.statement with '?'
+	TracerProvider     trace.TracerProvider // Optional tracer provider (default: the global provider)
+	MeterProvider      metric.MeterProvider // Optional meter provider (default: the global provider)
+}
+
+// dbTelemetry holds the tracer & instruments used to report on database activity.
+type dbTelemetry struct {
+	config       TelemetryConfig
+	tracer       trace.Tracer
+	meter        metric.Meter
+	duration     metric.Float64Histogram
+	open         metric.Int64ObservableGauge
+	inUse        metric.Int64ObservableGauge
+	idle         metric.Int64ObservableGauge
+	waitCount    metric.Int64ObservableCounter
+	waitDuration metric.Float64ObservableCounter
+}
+
+// SetupTelemetry enables (or disables) the OpenTelemetry instrumentation of database queries & connection pools.
+// It should be called after the telemetry providers are initialised and before calling OpenDBConnection.
+func SetupTelemetry(config TelemetryConfig) error {
+	if !config.Enabled {
+		telemetry.Store(nil)
+		return nil
+	}
+	t, err := newDBTelemetry(config)
+	if err != nil {
+		zlog.S.Errorf("Failed to setup database telemetry: %v", err)
+		return fmt.Errorf("failed to setup database telemetry: %v", err)
+	}
+	telemetry.Store(t)
+	return nil
+}
+
+// newDBTelemetry creates the tracer & instruments for the given config.
+func newDBTelemetry(config TelemetryConfig) (*dbTelemetry, error) {
+	tp := config.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := config.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	t := &dbTelemetry{config: config, tracer: tp.Tracer(instrumentationName), meter: mp.Meter(instrumentationName)}
+	var err, e error
+	t.duration, e = t.meter.Float64Histogram("db.client.operation.duration", metric.WithUnit("s"),
+		metric.WithDescription("Duration of database client operations"))
+	err = errors.Join(err, e)
+	t.open, e = t.meter.Int64ObservableGauge("db.client.connections.open", metric.WithUnit("{connection}"),
+		metric.WithDescription("Number of established connections, both in use and idle"))
+	err = errors.Join(err, e)
+	t.inUse, e = t.meter.Int64ObservableGauge("db.client.connections.in_use", metric.WithUnit("{connection}"),
+		metric.WithDescription("Number of connections currently in use"))
+	err = errors.Join(err, e)
+	t.idle, e = t.meter.Int64ObservableGauge("db.client.connections.idle", metric.WithUnit("{connection}"),
+		metric.WithDescription("Number of idle connections"))
+	err = errors.Join(err, e)
+	t.waitCount, e = t.meter.Int64ObservableCounter("db.client.connections.wait_count", metric.WithUnit("{wait}"),
+		metric.WithDescription("Total number of times a connection had to be waited for"))
+	err = errors.Join(err, e)
+	t.waitDuration, e = t.meter.Float64ObservableCounter("db.client.connections.wait_duration", metric.WithUnit("s"),
+		metric.WithDescription("Total time spent waiting for a connection"))
+	err = errors.Join(err, e)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// registerDBStats reports the connection pool statistics of the given DB, if telemetry is enabled.
+func registerDBStats(db *sqlx.DB, poolName string) {
+	t := telemetry.Load()
+	if t == nil || db == nil {
+		return
+	}
+	attrs := metric.WithAttributes(dbSystem(db.DriverName()), poolNameKey.String(poolName))
+	reg, err := t.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := db.Stats()
+		o.ObserveInt64(t.open, int64(stats.OpenConnections), attrs)
+		o.ObserveInt64(t.inUse, int64(stats.InUse), attrs)
+		o.ObserveInt64(t.idle, int64(stats.Idle), attrs)
+		o.ObserveInt64(t.waitCount, stats.WaitCount, attrs)
+		o.ObserveFloat64(t.waitDuration, stats.WaitDuration.Seconds(), attrs)
+		return nil
+	}, t.open, t.inUse, t.idle, t.waitCount, t.waitDuration)
+	if err != nil {
+		zlog.S.Warnf("Failed to register database pool metrics: %v", err)
+		return
+	}
+	statsLock.Lock()
+	defer statsLock.Unlock()
+	if previous, ok := statsRegistrations[db]; ok {
+		_ = previous.Unregister()
+	}
+	statsRegistrations[db] = reg
+}
+
+// unregisterDBStats stops reporting the connection pool statistics of the given DB.
+func unregisterDBStats(db *sqlx.DB) {
+	statsLock.Lock()
+	defer statsLock.Unlock()
+	if reg, ok := statsRegistrations[db]; ok {
+		if err := reg.Unregister(); err != nil {
+			zlog.S.Warnf("Problem unregistering database pool metrics: %v", err)
+		}
+		delete(statsRegistrations, db)
+	}
+}
+
+// querySpan tracks the span & timing of a single query.
+type querySpan struct {
+	t     *dbTelemetry
+	span  trace.Span
+	start time.Time
+	attrs []attribute.KeyValue
+}
+
+// startQuerySpan starts a client span for the given query. It returns a nil querySpan if telemetry is disabled.
+func (q *DBQueryContext) startQuerySpan(ctx context.Context, query string) (context.Context, *querySpan) {
+	t := telemetry.Load()
+	if t == nil {
+		return ctx, nil
+	}
+	operation := sqlOperation(query)
+	statement := query
+	if t.config.SanitizeStatements {
+		statement = SanitizeStatement(query)
+	}
+	attrs := []attribute.KeyValue{dbSystem(q.driverName())}
+	spanName := "db.query"
+	if len(operation) > 0 {
+		attrs = append(attrs, semconv.DBOperationKey.String(operation))
+		spanName = operation
+	}
+	ctx, span := t.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, semconv.DBStatementKey.String(statement))...))
+	return ctx, &querySpan{t: t, span: span, start: time.Now(), attrs: attrs}
+}
+
+// end records the outcome of the query (with any row count) and its duration.
+func (qs *querySpan) end(ctx context.Context, err error, rows ...attribute.KeyValue) {
+	if qs == nil {
+		return
+	}
+	qs.t.duration.Record(ctx, time.Since(qs.start).Seconds(), metric.WithAttributes(qs.attrs...))
+	if err != nil {
+		qs.span.RecordError(err)
+		qs.span.SetStatus(codes.Error, err.Error())
+	} else {
+		qs.span.SetAttributes(rows...)
+	}
+	qs.span.End()
+}
+
+// driverName returns the name of the driver the queries are run against, if known.
+func (q *DBQueryContext) driverName() string {
+	if q.tx != nil {
+		return q.tx.DriverName()
+	}
+	if q.db != nil {
+		return q.db.DriverName()
+	}
+	return ""
+}
+
+// dbSystem returns the OpenTelemetry db.system attribute for the given driver.
+func dbSystem(driver string) attribute.KeyValue {
+	switch driver {
+	case "postgres", "pgx":
+		return semconv.DBSystemPostgreSQL
+	case "sqlite", "sqlite3":
+		return semconv.DBSystemSqlite
+	case "":
+		return semconv.DBSystemOtherSQL
+	}
+	return semconv.DBSystemKey.String(driver)
+}
+
+// sqlOperation returns the leading keyword (i.e. SELECT) of the given statement.
+func sqlOperation(query string) string {
+	if match := operationRegex.FindStringSubmatch(query); match != nil {
+		return strings.ToUpper(match[1])
+	}
+	return ""
+}
+
+// SanitizeStatement replaces the string & numeric literals of the given SQL statement with '?',
+// so that it can be reported without leaking values. Bind parameters (i.e. $1) are left in place.
+func SanitizeStatement(query string) string {
+	query = stringLiteralRegex.ReplaceAllString(query, "?")
+	return numericLiteralRegex.ReplaceAllString(query, "${1}?")
+}
+
+// returnedRows returns the number of rows loaded into dest (a slice for SelectContext).
+func returnedRows(dest interface{}) attribute.KeyValue {
+	v := reflect.ValueOf(dest)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.IsValid() && v.Kind() == reflect.Slice {
+		return returnedRowsKey.Int(v.Len())
+	}
+	return returnedRowsKey.Int(1)
+}
+
+// rowsAffected returns the number of rows affected by a statement, if the driver reports it.
+func rowsAffected(result sql.Result) []attribute.KeyValue {
+	if result == nil {
+		return nil
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil
+	}
+	return []attribute.KeyValue{rowsAffectedKey.Int64(rows)}
+}
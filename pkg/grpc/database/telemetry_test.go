@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/lib/pq"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	_ "modernc.org/sqlite"
+)
+
+// setupTestTelemetry enables the database telemetry, recording into in-memory providers.
+func setupTestTelemetry(t *testing.T, sanitize bool) (*tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	err := SetupTelemetry(TelemetryConfig{Enabled: true, SanitizeStatements: sanitize,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = SetupTelemetry(TelemetryConfig{})
+	})
+	return recorder, reader
+}
+
+// collectMetrics returns the metrics recorded by the reader, by name.
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+// spanAttributes returns the attributes of the given span as a map.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestSanitizeStatement(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "SELECT * FROM person WHERE firstname = $1", want: "SELECT * FROM person WHERE firstname = $1"},
+		{query: "SELECT * FROM person WHERE firstname = 'harry' AND age > 17", want: "SELECT * FROM person WHERE firstname = ? AND age > ?"},
+		{query: "INSERT INTO t2 (a, b) VALUES ('it''s', -1.5)", want: "INSERT INTO t2 (a, b) VALUES (?, ?)"},
+		{query: "SELECT col1 FROM t WHERE id IN (1,2)", want: "SELECT col1 FROM t WHERE id IN (?,?)"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeStatement(tt.query); got != tt.want {
+			t.Errorf("SanitizeStatement(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSQLOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM person":                 "SELECT",
+		"  insert into person values ($1)":     "INSERT",
+		"(SELECT 1) UNION (SELECT 2)":          "SELECT",
+		"WITH x AS (SELECT 1) SELECT * FROM x": "WITH",
+		"":                                     "",
+	}
+	for query, want := range tests {
+		if got := sqlOperation(query); got != want {
+			t.Errorf("sqlOperation(%q) = %q, want %q", query, got, want)
+		}
+	}
+	if got := dbSystem("postgres").Value.AsString(); got != "postgresql" {
+		t.Errorf("Expected postgresql db.system, got %v", got)
+	}
+	if got := dbSystem("sqlite3").Value.AsString(); got != "sqlite" {
+		t.Errorf("Expected sqlite db.system, got %v", got)
+	}
+}
+
+func TestQueryTelemetry(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	recorder, reader := setupTestTelemetry(t, true)
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	ctx := context.Background()
+	q := NewDBSelectContext(zlog.S, db, nil, false)
+	if _, err = q.ExecContext(ctx, "INSERT INTO person (firstname, lastname) VALUES ('harry', 'potter'), ('ron', 'weasley')"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var results []Persons
+	if err = q.SelectContext(ctx, &results, "SELECT * FROM person WHERE lastname <> $1", "granger"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %v", len(spans))
+	}
+	insert, sel := spanAttributes(spans[0]), spanAttributes(spans[1])
+	if spans[0].Name() != "INSERT" || spans[1].Name() != "SELECT" {
+		t.Errorf("Unexpected span names: %v, %v", spans[0].Name(), spans[1].Name())
+	}
+	if got := insert["db.statement"].AsString(); got != "INSERT INTO person (firstname, lastname) VALUES (?, ?), (?, ?)" {
+		t.Errorf("Expected sanitized statement, got %v", got)
+	}
+	if got := insert[rowsAffectedKey].AsInt64(); got != 2 {
+		t.Errorf("Expected 2 rows affected, got %v", got)
+	}
+	if got := sel[returnedRowsKey].AsInt64(); got != 2 {
+		t.Errorf("Expected 2 returned rows, got %v", got)
+	}
+	if got := sel["db.system"].AsString(); got != "sqlite" {
+		t.Errorf("Expected sqlite db.system, got %v", got)
+	}
+	metrics := collectMetrics(t, reader)
+	histogram, ok := metrics["db.client.operation.duration"].(metricdata.Histogram[float64])
+	if !ok || len(histogram.DataPoints) != 2 {
+		t.Errorf("Expected query durations for both operations, got %v", metrics["db.client.operation.duration"])
+	}
+	if _, ok = metrics["db.client.connections.open"]; !ok {
+		t.Errorf("Expected connection pool metrics, got %v", metrics)
+	}
+}
+
+func TestQueryTelemetryError(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	recorder, _ := setupTestTelemetry(t, false)
+	db, err := OpenDBConnection("", "postgres", "user", "passwd", "127.0.0.1:1", "scanoss", "disable")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	q := NewDBSelectContext(zlog.S, db, nil, false)
+	var count int
+	if err = q.GetContext(context.Background(), &count, "SELECT count(*) FROM person WHERE age > 17"); err == nil {
+		t.Fatalf("Expected an error connecting to an unreachable database")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %v", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("Expected an error status, got %v", spans[0].Status())
+	}
+	attrs := spanAttributes(spans[0])
+	if got := attrs["db.statement"].AsString(); got != "SELECT count(*) FROM person WHERE age > 17" {
+		t.Errorf("Expected the raw statement, got %v", got)
+	}
+	if got := attrs["db.system"].AsString(); got != "postgresql" {
+		t.Errorf("Expected postgresql db.system, got %v", got)
+	}
+	if _, ok := attrs[returnedRowsKey]; ok {
+		t.Errorf("Expected no row count on a failed query")
+	}
+}
+
+func TestDBStatsMetrics(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	_, reader := setupTestTelemetry(t, false)
+	db, err := OpenDBConnection("", "postgres", "user", "passwd", "127.0.0.1:1", "scanoss", "disable")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	metrics := collectMetrics(t, reader)
+	for _, name := range []string{"db.client.connections.open", "db.client.connections.in_use", "db.client.connections.idle",
+		"db.client.connections.wait_count", "db.client.connections.wait_duration"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("Expected metric %v, got %v", name, metrics)
+		}
+	}
+	gauge, ok := metrics["db.client.connections.open"].(metricdata.Gauge[int64])
+	if !ok || len(gauge.DataPoints) != 1 {
+		t.Fatalf("Expected a single open connections data point, got %v", metrics["db.client.connections.open"])
+	}
+	if pool, _ := gauge.DataPoints[0].Attributes.Value(poolNameKey); pool.AsString() != "127.0.0.1:1/scanoss" {
+		t.Errorf("Expected pool name 127.0.0.1:1/scanoss, got %v", pool.AsString())
+	}
+	CloseDBConnection(db)
+	metrics = collectMetrics(t, reader)
+	if gauge, ok = metrics["db.client.connections.open"].(metricdata.Gauge[int64]); ok && len(gauge.DataPoints) > 0 {
+		t.Errorf("Expected no pool metrics after closing the DB, got %v", gauge.DataPoints)
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTxMaxRetries   = 3
+	defaultTxRetryBackoff = 50 * time.Millisecond
+	sqliteBusy            = 5 // SQLITE_BUSY primary result code
+)
+
+// retryableSQLStates are the Postgres serialization failure (40001) & deadlock detected (40P01) codes.
+var retryableSQLStates = map[string]bool{"40001": true, "40P01": true}
+
+// TxOptions controls how WithTx runs a transaction. Zero values keep the defaults
+// (driver default isolation, read-write, 3 retries with a 50ms backoff, global logger, no tracing).
+type TxOptions struct {
+	Isolation    sql.IsolationLevel // Transaction isolation level (i.e. sql.LevelSerializable)
+	ReadOnly     bool               // Start a read-only transaction
+	MaxRetries   int                // Maximum retries on serialization/deadlock/busy errors (negative = no retries)
+	RetryBackoff time.Duration      // Wait before the first retry, doubled after each further retry
+	Logger       *zap.SugaredLogger // Logger used for query tracing
+	Trace        bool               // Log the queries & results run in the transaction
+}
+
+// TxQueryContext runs traced queries (see DBQueryContext) inside a transaction.
+type TxQueryContext struct {
+	DBQueryContext
+}
+
+// Tx returns the underlying transaction.
+func (t *TxQueryContext) Tx() *sqlx.Tx {
+	return t.tx
+}
+
+// IsRetryableTxError checks if the error is a transient serialization, deadlock or database busy failure.
+func IsRetryableTxError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var sqlState interface{ SQLState() string } // lib/pq & pgx errors
+	if errors.As(err, &sqlState) && retryableSQLStates[sqlState.SQLState()] {
+		return true
+	}
+	var sqliteErr interface{ Code() int } // modernc.org/sqlite errors (extended codes keep the primary code in the low byte)
+	if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqliteBusy {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "SQLITE_BUSY") || strings.Contains(msg, "database is locked")
+}
+
+// WithTx runs fn inside a transaction, committing if it succeeds and rolling back if it returns an error or panics.
+// The whole transaction is retried (with exponential backoff) if it fails with a serialization, deadlock or busy error,
+// so fn must be safe to run more than once. A nil opts uses the defaults.
+func WithTx(ctx context.Context, db *sqlx.DB, opts *TxOptions, fn func(tx *TxQueryContext) error) error {
+	if db == nil {
+		return errors.New("no database supplied")
+	}
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultTxMaxRetries
+	}
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultTxRetryBackoff
+	}
+	s := opts.Logger
+	if s == nil {
+		s = zlog.S
+	}
+	for attempt := 0; ; attempt++ {
+		err := runTx(ctx, db, opts, s, fn)
+		if err == nil || !IsRetryableTxError(err) || attempt >= maxRetries {
+			return err
+		}
+		s.Warnf("Transaction attempt %d failed, retrying in %v: %v", attempt+1, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// runTx runs a single attempt of the transaction.
+func runTx(ctx context.Context, db *sqlx.DB, opts *TxOptions, s *zap.SugaredLogger, fn func(tx *TxQueryContext) error) (err error) {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		s.Errorf("Failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollbackTx(tx, s)
+			panic(p) // re-throw after rolling back
+		}
+	}()
+	if err = fn(&TxQueryContext{DBQueryContext{db: db, tx: tx, s: s, trace: opts.Trace}}); err != nil {
+		rollbackTx(tx, s)
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		s.Errorf("Failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// rollbackTx rolls back the transaction, logging any problem.
+func rollbackTx(tx *sqlx.Tx, s *zap.SugaredLogger) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		s.Warnf("Problem rolling back transaction: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteCodeError mimics the modernc.org/sqlite errors.
+type sqliteCodeError struct {
+	code int
+}
+
+func (e *sqliteCodeError) Error() string {
+	return fmt.Sprintf("sqlite error %d", e.code)
+}
+
+func (e *sqliteCodeError) Code() int {
+	return e.code
+}
+
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "serialization failure", err: &sqlStateError{code: "40001"}, want: true},
+		{name: "deadlock", err: fmt.Errorf("failed to commit transaction: %w", &sqlStateError{code: "40P01"}), want: true},
+		{name: "unique violation", err: &sqlStateError{code: "23505"}, want: false},
+		{name: "sqlite busy", err: &sqliteCodeError{code: 5}, want: true},
+		{name: "sqlite busy snapshot", err: &sqliteCodeError{code: 517}, want: true},
+		{name: "sqlite constraint", err: &sqliteCodeError{code: 19}, want: false},
+		{name: "locked message", err: errors.New("database is locked"), want: true},
+		{name: "other", err: errors.New("no such table: person"), want: false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableTxError(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := OpenDBConnection(":memory:", "sqlite", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer CloseDBConnection(db)
+	db.SetMaxOpenConns(1) // keep a single in-memory DB
+	db.MustExec("CREATE TABLE person (firstname text, lastname text)")
+	ctx := context.Background()
+	count := func() int {
+		var n int
+		if err := db.GetContext(ctx, &n, "SELECT count(*) FROM person"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return n
+	}
+	insert := func(tx *TxQueryContext) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO person (firstname, lastname) VALUES ($1, $2)", "harry", "potter")
+		return err
+	}
+	opts := &TxOptions{RetryBackoff: time.Millisecond, Trace: true}
+	// Commit
+	if err = WithTx(ctx, db, opts, insert); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("Expected the insert to be committed, got %v rows", n)
+	}
+	// Rollback on error
+	fnErr := errors.New("validation failed")
+	err = WithTx(ctx, db, opts, func(tx *TxQueryContext) error {
+		if err := insert(tx); err != nil {
+			return err
+		}
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Errorf("Expected the function error, got %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("Expected the insert to be rolled back, got %v rows", n)
+	}
+	// Rollback on panic
+	func() {
+		defer func() {
+			if p := recover(); p == nil {
+				t.Errorf("Expected the panic to be re-thrown")
+			}
+		}()
+		_ = WithTx(ctx, db, opts, func(tx *TxQueryContext) error {
+			_ = insert(tx)
+			panic("boom")
+		})
+	}()
+	if n := count(); n != 1 {
+		t.Errorf("Expected the insert to be rolled back after a panic, got %v rows", n)
+	}
+	// Retry on serialization failures
+	attempts := 0
+	err = WithTx(ctx, db, opts, func(tx *TxQueryContext) error {
+		attempts++
+		if err := insert(tx); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return &sqlStateError{code: "40001"}
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Errorf("Expected success after 3 attempts, got %v attempts (%v)", attempts, err)
+	}
+	if n := count(); n != 2 {
+		t.Errorf("Expected only the successful attempt to be committed, got %v rows", n)
+	}
+	// Retries exhausted & non-retryable errors
+	for name, tc := range map[string]struct {
+		opts *TxOptions
+		err  error
+		want int
+	}{
+		"exhausted":     {&TxOptions{MaxRetries: 2, RetryBackoff: time.Millisecond}, &sqlStateError{code: "40P01"}, 3},
+		"no retries":    {&TxOptions{MaxRetries: -1}, &sqlStateError{code: "40P01"}, 1},
+		"not retryable": {nil, &sqlStateError{code: "23505"}, 1},
+	} {
+		attempts = 0
+		err = WithTx(ctx, db, tc.opts, func(tx *TxQueryContext) error {
+			attempts++
+			return tc.err
+		})
+		if !errors.Is(err, tc.err) || attempts != tc.want {
+			t.Errorf("%s: expected %v attempts, got %v (%v)", name, tc.want, attempts, err)
+		}
+	}
+	// Cancelled while backing off
+	cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err = WithTx(cancelCtx, db, &TxOptions{MaxRetries: 10, RetryBackoff: time.Second}, func(tx *TxQueryContext) error {
+		return &sqlStateError{code: "40001"}
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context deadline to stop the retries, got %v", err)
+	}
+	if tx := (&TxQueryContext{}).Tx(); tx != nil {
+		t.Errorf("Expected no transaction, got %v", tx)
+	}
+	if err = WithTx(ctx, nil, nil, insert); err == nil {
+		t.Errorf("Expected an error with no database")
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+// Package fieldmask prunes response messages down to a client requested set of field paths (partial responses).
+package fieldmask
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MetadataKey is the gRPC metadata key used to request a partial response (i.e. "x-field-mask: purl,versions.version").
+const MetadataKey = "x-field-mask"
+
+// statusField is the top level response field that is never pruned.
+const statusField = "status"
+
+// fieldTree is a parsed set of field paths, keyed by field name.
+// An empty subtree means the whole field is kept.
+type fieldTree map[string]fieldTree
+
+// ParsePaths splits a comma separated list of dotted field paths, dropping empty entries.
+func ParsePaths(mask string) []string {
+	var paths []string
+	for _, path := range strings.Split(mask, ",") {
+		path = strings.TrimSpace(path)
+		if len(path) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Prune clears every field of the message not covered by the given paths.
+// Paths are dotted field names (proto or JSON names) and may descend into messages, repeated messages and map values.
+// The top level "status" field is always kept whole. No pruning is done if no paths are supplied.
+func Prune(msg proto.Message, paths []string) {
+	if msg == nil || len(paths) == 0 {
+		return
+	}
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			child, ok := node[name]
+			if ok && len(child) == 0 {
+				break // the field is already fully kept
+			}
+			if i == len(names)-1 {
+				node[name] = fieldTree{} // keep the whole field, dropping any narrower paths
+				break
+			}
+			if !ok {
+				child = fieldTree{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	tree[statusField] = fieldTree{} // always keep the whole status, whatever status sub-paths were requested
+	pruneMessage(msg.ProtoReflect(), tree)
+}
+
+// pruneMessage clears the fields of the message not in the tree, recursing into the kept subtrees.
+func pruneMessage(m protoreflect.Message, tree fieldTree) {
+	var cleared []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		subtree, ok := tree[string(fd.Name())]
+		if !ok {
+			subtree, ok = tree[fd.JSONName()]
+		}
+		switch {
+		case !ok:
+			cleared = append(cleared, fd)
+		case len(subtree) > 0:
+			pruneValue(fd, v, subtree)
+		}
+		return true
+	})
+	for _, fd := range cleared {
+		m.Clear(fd)
+	}
+}
+
+// pruneValue applies the subtree to a message, list of messages or map of messages field value.
+func pruneValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, tree fieldTree) {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return
+		}
+		v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+			pruneMessage(mv.Message(), tree)
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() == nil {
+			return
+		}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			pruneMessage(list.Get(i).Message(), tree)
+		}
+	case fd.Message() != nil:
+		pruneMessage(v.Message(), tree)
+	}
+}
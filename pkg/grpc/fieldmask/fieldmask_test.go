@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package fieldmask
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testResponse builds a dynamic response message with a status, scalar, repeated message and map fields.
+func testResponse(t *testing.T) proto.Message {
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Label: label.Enum(), Type: typ.Enum()}
+		if len(typeName) > 0 {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	str, msg := descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("fieldmask_test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Status"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("status", 1, optional, str, ""),
+				field("message", 2, optional, str, ""),
+			}},
+			{Name: proto.String("Version"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("version", 1, optional, str, ""),
+				field("license_id", 2, optional, str, ""),
+			}},
+			{Name: proto.String("Response"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("status", 1, optional, msg, ".test.Status"),
+				field("purl", 2, optional, str, ""),
+				field("component_name", 3, optional, str, ""),
+				field("versions", 4, repeated, msg, ".test.Version"),
+				field("latest", 5, optional, msg, ".test.Version"),
+				field("by_version", 6, repeated, msg, ".test.Response.ByVersionEntry"),
+			}, NestedType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("ByVersionEntry"), Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, optional, str, ""),
+						field("value", 2, optional, msg, ".test.Version"),
+					}},
+			}},
+		},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error building the test descriptor: %v", err)
+	}
+	resp := dynamicpb.NewMessage(fd.Messages().ByName(protoreflect.Name("Response")))
+	err = protojson.Unmarshal([]byte(`{
+		"status": {"status": "SUCCESS", "message": "ok"},
+		"purl": "pkg:github/scanoss/engine",
+		"componentName": "engine",
+		"versions": [{"version": "1.0", "licenseId": "MIT"}, {"version": "2.0", "licenseId": "GPL-2.0"}],
+		"latest": {"version": "2.0", "licenseId": "GPL-2.0"},
+		"byVersion": {"1.0": {"version": "1.0", "licenseId": "MIT"}}
+	}`), resp)
+	if err != nil {
+		t.Fatalf("Unexpected error building the test response: %v", err)
+	}
+	return resp
+}
+
+func TestParsePaths(t *testing.T) {
+	tests := []struct {
+		mask string
+		want []string
+	}{
+		{mask: "", want: nil},
+		{mask: "purl", want: []string{"purl"}},
+		{mask: " purl, versions.version ,,", want: []string{"purl", "versions.version"}},
+	}
+	for _, tt := range tests {
+		if got := ParsePaths(tt.mask); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParsePaths(%q) = %v, want %v", tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{name: "no paths", paths: nil,
+			want: `{"status":{"status":"SUCCESS","message":"ok"},"purl":"pkg:github/scanoss/engine","componentName":"engine",` +
+				`"versions":[{"version":"1.0","licenseId":"MIT"},{"version":"2.0","licenseId":"GPL-2.0"}],` +
+				`"latest":{"version":"2.0","licenseId":"GPL-2.0"},"byVersion":{"1.0":{"version":"1.0","licenseId":"MIT"}}}`},
+		{name: "top level", paths: []string{"purl"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"},"purl":"pkg:github/scanoss/engine"}`},
+		{name: "json names", paths: []string{"componentName", "latest.licenseId"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"},"componentName":"engine","latest":{"licenseId":"GPL-2.0"}}`},
+		{name: "repeated and map", paths: []string{"versions.version", "by_version.license_id"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"},"versions":[{"version":"1.0"},{"version":"2.0"}],` +
+				`"byVersion":{"1.0":{"licenseId":"MIT"}}}`},
+		{name: "wider path wins", paths: []string{"latest.version", "latest"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"},"latest":{"version":"2.0","licenseId":"GPL-2.0"}}`},
+		{name: "status subpath", paths: []string{"status.status"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"}}`},
+		{name: "unknown field", paths: []string{"missing"},
+			want: `{"status":{"status":"SUCCESS","message":"ok"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := testResponse(t)
+			Prune(resp, tt.paths)
+			got, err := protojson.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			want := resp.ProtoReflect().New().Interface()
+			if err = protojson.Unmarshal([]byte(tt.want), want); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(resp, want) {
+				t.Errorf("Prune(%v) = %s, want %s", tt.paths, got, tt.want)
+			}
+		})
+	}
+	Prune(nil, []string{"purl"}) // should not panic
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// etagMetadataKey is the gRPC header metadata key a service can set to supply its own response ETag.
+const etagMetadataKey = "etag"
+
+// RouteCacheControl sets the Cache-Control header of successful GET responses whose path matches Pattern.
+type RouteCacheControl struct {
+	Pattern string // URL path pattern, using path.Match syntax (i.e. "/v2/components/*"). The first matching entry wins
+	Value   string // Cache-Control header value (i.e. "public, max-age=3600")
+}
+
+// CacheConfig controls the HTTP caching headers of the gateway GET responses.
+type CacheConfig struct {
+	ETags        bool                // Add strong ETags to GET responses & answer matching If-None-Match requests with 304 Not Modified
+	CacheControl []RouteCacheControl // Per-route Cache-Control headers
+}
+
+// WithCaching enables ETags, conditional GET requests and per-route Cache-Control headers.
+// Services can supply their own ETag by setting the "etag" gRPC header metadata, otherwise it is computed from the response body.
+func WithCaching(config CacheConfig) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.cache = &config
+	}
+}
+
+// cacheHeaderForwarding adds the ETag metadata to the forwarded response headers, if ETags are enabled.
+func cacheHeaderForwarding(rules HeaderForwarding, config *CacheConfig) HeaderForwarding {
+	if config != nil && config.ETags {
+		rules.OutgoingHeaders = append(append([]string{}, rules.OutgoingHeaders...), etagMetadataKey)
+	}
+	return rules
+}
+
+// cacheControl returns the Cache-Control value configured for the given path (if any).
+func cacheControl(routes []RouteCacheControl, urlPath string) string {
+	for _, route := range routes {
+		matched, err := path.Match(route.Pattern, urlPath)
+		if err != nil {
+			zlog.S.Warnf("Invalid cache control route pattern %s: %v", route.Pattern, err)
+			continue
+		}
+		if matched {
+			return route.Value
+		}
+	}
+	return ""
+}
+
+// strongETag returns the quoted ETag for the given response body.
+func strongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// quoteETag makes sure a service supplied ETag is a valid (quoted) entity tag.
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return strconv.Quote(etag)
+}
+
+// etagMatch checks if the If-None-Match header value matches the given ETag (using weak comparison).
+func etagMatch(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheWriter buffers a response so its ETag can be computed before anything is sent.
+// Flushing (i.e. a streaming response) switches it to pass-through mode, without caching headers.
+type cacheWriter struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	passThrough bool
+}
+
+func (c *cacheWriter) WriteHeader(code int) {
+	if c.passThrough {
+		c.ResponseWriter.WriteHeader(code)
+	} else if c.status == 0 {
+		c.status = code
+	}
+}
+
+func (c *cacheWriter) Write(b []byte) (int, error) {
+	if c.passThrough {
+		return c.ResponseWriter.Write(b)
+	}
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	return c.body.Write(b)
+}
+
+// Flush sends everything buffered so far and stops buffering the rest of the response.
+func (c *cacheWriter) Flush() {
+	if !c.passThrough {
+		c.passThrough = true
+		c.send()
+	}
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer.
+func (c *cacheWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// send writes the buffered status & body to the underlying writer.
+func (c *cacheWriter) send() {
+	if c.status != 0 {
+		c.ResponseWriter.WriteHeader(c.status)
+	}
+	if c.body.Len() > 0 {
+		if _, err := c.ResponseWriter.Write(c.body.Bytes()); err != nil {
+			zlog.S.Debugf("Failed to write the response: %v", err)
+		}
+	}
+}
+
+// cacheHandler adds ETag & Cache-Control headers to successful GET/HEAD responses and answers conditional requests.
+func cacheHandler(next http.Handler, config *CacheConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &cacheWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, r)
+		if cw.passThrough {
+			return
+		}
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		if cw.status != http.StatusOK {
+			cw.send()
+			return
+		}
+		if value := cacheControl(config.CacheControl, r.URL.Path); len(value) > 0 && len(w.Header().Get("Cache-Control")) == 0 {
+			w.Header().Set("Cache-Control", value)
+		}
+		var etag string
+		if config.ETags {
+			etag = w.Header().Get("ETag")
+			if len(etag) == 0 {
+				etag = strongETag(cw.body.Bytes())
+			} else {
+				etag = quoteETag(etag)
+			}
+			w.Header().Set("ETag", etag)
+		}
+		if len(etag) > 0 || len(w.Header().Get("Cache-Control")) > 0 {
+			// The response body depends on the requested format (i.e. JSON or protobuf), so caches need to key on it
+			w.Header().Add("Vary", "Accept")
+		}
+		if ifNoneMatch := r.Header.Get("If-None-Match"); len(etag) > 0 && len(ifNoneMatch) > 0 && etagMatch(ifNoneMatch, etag) {
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		cw.send()
+	})
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// setupCachingGateway creates a gateway with test routes returning a fixed message, a service supplied ETag, an error & a stream.
+func setupCachingGateway(t *testing.T, config CacheConfig) http.Handler {
+	handler, mux := setupTestGateway(t, WithCaching(config))
+	routes := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v2/components/info": func(w http.ResponseWriter, r *http.Request) {
+			ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+			_, outbound := runtime.MarshalerForRequest(mux, r)
+			runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, wrapperspb.String("info"))
+		},
+		"/v2/components/tagged": func(w http.ResponseWriter, r *http.Request) {
+			ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{HeaderMD: metadata.Pairs("etag", "kb-2026.10")})
+			_, outbound := runtime.MarshalerForRequest(mux, r)
+			runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, wrapperspb.String("tagged"))
+		},
+		"/v2/components/missing": func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(w, http.StatusNotFound, "not found")
+		},
+		"/v2/stream": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("chunk\n"))
+			http.NewResponseController(w).Flush()
+			_, _ = w.Write([]byte("chunk\n"))
+		},
+	}
+	for route, handle := range routes {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			handleTestPath(t, mux, method, route, handle)
+		}
+	}
+	return handler
+}
+
+func TestGatewayCaching(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	config := CacheConfig{ETags: true, CacheControl: []RouteCacheControl{
+		{Pattern: "/v2/components/*", Value: "public, max-age=3600"},
+		{Pattern: "/v2/*", Value: "no-store"},
+	}}
+	infoETag := strongETag([]byte(`"info"`))
+	tests := []struct {
+		name         string
+		config       CacheConfig
+		method       string
+		path         string
+		ifNoneMatch  string
+		status       int
+		etag         string
+		cacheControl string
+		body         string
+	}{
+		{name: "computed etag", config: config, path: "/v2/components/info", status: http.StatusOK, etag: infoETag,
+			cacheControl: "public, max-age=3600", body: `"info"`},
+		{name: "not modified", config: config, path: "/v2/components/info", ifNoneMatch: `"other", ` + infoETag,
+			status: http.StatusNotModified, etag: infoETag, cacheControl: "public, max-age=3600"},
+		{name: "weak not modified", config: config, path: "/v2/components/info", ifNoneMatch: "W/" + infoETag,
+			status: http.StatusNotModified, etag: infoETag, cacheControl: "public, max-age=3600"},
+		{name: "modified", config: config, path: "/v2/components/info", ifNoneMatch: `"stale"`, status: http.StatusOK,
+			etag: infoETag, cacheControl: "public, max-age=3600", body: `"info"`},
+		{name: "service etag", config: config, path: "/v2/components/tagged", status: http.StatusOK, etag: `"kb-2026.10"`,
+			cacheControl: "public, max-age=3600", body: `"tagged"`},
+		{name: "service etag not modified", config: config, path: "/v2/components/tagged", ifNoneMatch: `"kb-2026.10"`,
+			status: http.StatusNotModified, etag: `"kb-2026.10"`, cacheControl: "public, max-age=3600"},
+		{name: "post", config: config, method: http.MethodPost, path: "/v2/components/info", ifNoneMatch: infoETag,
+			status: http.StatusOK, body: `"info"`},
+		{name: "error", config: config, path: "/v2/components/missing", status: http.StatusNotFound,
+			body: `{"status":{"status":"FAILED","message":"not found"}}`},
+		{name: "streaming", config: config, path: "/v2/stream", status: http.StatusOK, body: "chunk\nchunk\n"},
+		{name: "cache control only", config: CacheConfig{CacheControl: config.CacheControl}, path: "/v2/components/info",
+			ifNoneMatch: infoETag, status: http.StatusOK, cacheControl: "public, max-age=3600", body: `"info"`},
+		{name: "etags only", config: CacheConfig{ETags: true}, path: "/v2/components/info", status: http.StatusOK,
+			etag: infoETag, body: `"info"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupCachingGateway(t, tt.config)
+			method := tt.method
+			if len(method) == 0 {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.path, nil)
+			if len(tt.ifNoneMatch) > 0 {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			if rec.Header().Get("ETag") != tt.etag {
+				t.Errorf("Expected ETag %v, got %v", tt.etag, rec.Header().Get("ETag"))
+			}
+			if rec.Header().Get("Cache-Control") != tt.cacheControl {
+				t.Errorf("Expected Cache-Control %v, got %v", tt.cacheControl, rec.Header().Get("Cache-Control"))
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, rec.Body.String())
+			}
+			vary := ""
+			if len(tt.etag) > 0 || len(tt.cacheControl) > 0 {
+				vary = "Accept"
+			}
+			if rec.Header().Get("Vary") != vary {
+				t.Errorf("Expected Vary %q, got %q", vary, rec.Header().Get("Vary"))
+			}
+			if len(rec.Header().Get("Grpc-Metadata-Etag")) > 0 {
+				t.Errorf("Expected the ETag metadata not to be exposed with a prefix, got %v", rec.Header())
+			}
+		})
+	}
+}
+
+func TestETagMatch(t *testing.T) {
+	tests := []struct {
+		ifNoneMatch string
+		etag        string
+		want        bool
+	}{
+		{ifNoneMatch: `"a"`, etag: `"a"`, want: true},
+		{ifNoneMatch: `"b", "a"`, etag: `"a"`, want: true},
+		{ifNoneMatch: `*`, etag: `"a"`, want: true},
+		{ifNoneMatch: `W/"a"`, etag: `"a"`, want: true},
+		{ifNoneMatch: `"a"`, etag: `W/"a"`, want: true},
+		{ifNoneMatch: `"b"`, etag: `"a"`, want: false},
+	}
+	for _, tt := range tests {
+		if got := etagMatch(tt.ifNoneMatch, tt.etag); got != tt.want {
+			t.Errorf("etagMatch(%q, %q) = %v, want %v", tt.ifNoneMatch, tt.etag, got, tt.want)
+		}
+	}
+	if got := quoteETag("v1"); got != `"v1"` {
+		t.Errorf("Expected a quoted ETag, got %v", got)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	defaultCORSHeaders = []string{"Accept", "Content-Type", "Authorization"}
+)
+
+// CORSConfig controls the Cross-Origin Resource Sharing headers of the gateway (REST & gRPC-Web).
+type CORSConfig struct {
+	AllowedOrigins   []string      // Origins allowed to call the gateway (i.e. https://scanoss.com). "*" allows any origin
+	AllowedMethods   []string      // Methods allowed in cross-origin requests (default: GET, HEAD, POST, PUT, PATCH, DELETE)
+	AllowedHeaders   []string      // Request headers allowed in cross-origin requests (default: Accept, Content-Type, Authorization)
+	ExposedHeaders   []string      // Response headers made visible to the browser
+	AllowCredentials bool          // Allow cookies & HTTP authentication in cross-origin requests. Cannot be used with the "*" origin
+	MaxAge           time.Duration // How long browsers can cache the preflight response (0 = browser default)
+}
+
+// WithCORS enables Cross-Origin Resource Sharing for the given origins.
+func WithCORS(config CORSConfig) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.cors = &config
+	}
+}
+
+// validate rejects configurations that would let any origin make credentialed requests.
+func (c *CORSConfig) validate() error {
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return fmt.Errorf("credentials cannot be allowed for the \"*\" origin, list the allowed origins instead")
+	}
+	return nil
+}
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for the given origin, or an empty string if it's not allowed.
+func (c *CORSConfig) allowedOrigin(origin string) string {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*"
+		}
+		if strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// corsHandler adds the CORS headers to requests from allowed origins and answers their preflight requests.
+// Requests from other origins are passed on untouched (so the browser blocks them).
+// The gRPC-Web request & response headers are allowed automatically when gRPC-Web is enabled.
+func corsHandler(next http.Handler, config *CORSConfig, grpcWeb bool) http.Handler {
+	methods := config.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := config.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	exposed := config.ExposedHeaders
+	if grpcWeb {
+		headers = append(append([]string{}, headers...), grpcWebRequestHeaders...)
+		exposed = append(append([]string{}, exposed...), grpcWebResponseHeaders...)
+	}
+	allowMethods, allowHeaders, exposeHeaders := strings.Join(methods, ", "), strings.Join(headers, ", "), strings.Join(exposed, ", ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(origin) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		allowOrigin := config.allowedOrigin(origin)
+		if len(allowOrigin) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.Set("Access-Control-Allow-Origin", allowOrigin)
+		if config.AllowCredentials && allowOrigin != "*" { // never send credentials to any origin
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if r.Method == http.MethodOptions && len(r.Header.Get("Access-Control-Request-Method")) > 0 { // preflight request
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			h.Set("Access-Control-Allow-Methods", allowMethods)
+			h.Set("Access-Control-Allow-Headers", allowHeaders)
+			if config.MaxAge > 0 {
+				h.Set("Access-Control-Max-Age", strconv.FormatInt(int64(config.MaxAge.Seconds()), 10))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if len(exposeHeaders) > 0 {
+			h.Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
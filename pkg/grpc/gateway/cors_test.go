@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestCORSHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	config := CORSConfig{AllowedOrigins: []string{"https://scanoss.com"}, ExposedHeaders: []string{"ETag"}, MaxAge: 10 * time.Minute}
+	tests := []struct {
+		name    string
+		config  CORSConfig
+		grpcWeb bool
+		method  string
+		origin  string
+		status  int
+		want    map[string]string
+	}{
+		{name: "no origin", config: config, method: http.MethodGet, status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Origin": "", "Vary": ""}},
+		{name: "allowed", config: config, method: http.MethodGet, origin: "https://scanoss.com", status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Origin": "https://scanoss.com", "Access-Control-Expose-Headers": "ETag", "Vary": "Origin"}},
+		{name: "not allowed", config: config, method: http.MethodGet, origin: "https://example.com", status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"}},
+		{name: "preflight", config: config, method: http.MethodOptions, origin: "https://scanoss.com", status: http.StatusNoContent,
+			want: map[string]string{"Access-Control-Allow-Origin": "https://scanoss.com", "Access-Control-Max-Age": "600",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST, PUT, PATCH, DELETE", "Access-Control-Allow-Headers": "Accept, Content-Type, Authorization"}},
+		{name: "preflight grpc-web", config: config, grpcWeb: true, method: http.MethodOptions, origin: "https://scanoss.com", status: http.StatusNoContent,
+			want: map[string]string{"Access-Control-Allow-Headers": "Accept, Content-Type, Authorization, X-Grpc-Web, X-User-Agent, Grpc-Timeout"}},
+		{name: "preflight not allowed", config: config, method: http.MethodOptions, origin: "https://example.com", status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Methods": ""}},
+		{name: "wildcard", config: CORSConfig{AllowedOrigins: []string{"*"}}, method: http.MethodGet, origin: "https://example.com",
+			status: http.StatusOK, want: map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": ""}},
+		{name: "wildcard credentials", config: CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, method: http.MethodGet,
+			origin: "https://example.com", status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": ""}},
+		{name: "credentials", config: CORSConfig{AllowedOrigins: []string{"https://scanoss.com"}, AllowCredentials: true}, method: http.MethodGet,
+			origin: "https://scanoss.com", status: http.StatusOK,
+			want: map[string]string{"Access-Control-Allow-Origin": "https://scanoss.com", "Access-Control-Allow-Credentials": "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/v2/components", nil)
+			if len(tt.origin) > 0 {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rec := httptest.NewRecorder()
+			corsHandler(next, &tt.config, tt.grpcWeb).ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			for name, value := range tt.want {
+				if got := strings.Join(rec.Header().Values(name), ","); !strings.HasPrefix(got, value) || (len(value) == 0 && len(got) > 0) {
+					t.Errorf("Expected %v header %q, got %q", name, value, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGatewayCORSValidation(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name   string
+		config CORSConfig
+		fail   bool
+	}{
+		{name: "origins", config: CORSConfig{AllowedOrigins: []string{"https://scanoss.com"}, AllowCredentials: true}},
+		{name: "wildcard", config: CORSConfig{AllowedOrigins: []string{"*"}}},
+		{name: "wildcard credentials", config: CORSConfig{AllowedOrigins: []string{"https://scanoss.com", "*"}, AllowCredentials: true}, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, _, err := SetupGateway("9443", "8443", "", "", nil, nil, false, false, false, WithCORS(tt.config))
+			if (err != nil) != tt.fail {
+				t.Errorf("Unexpected error result: %v", err)
+			}
+		})
+	}
+}
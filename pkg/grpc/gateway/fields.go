@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/fieldmask"
+)
+
+// fieldsQueryParam is the query parameter used to request a partial response (i.e. "?fields=purl,versions.version").
+const fieldsQueryParam = "fields"
+
+// fieldMaskKey is the request context key holding the requested partial response field paths.
+type fieldMaskKey struct{}
+
+// WithFieldMasks enables partial responses, pruning each response to the paths listed in the "fields" query parameter.
+// The paths are also forwarded upstream in the "x-field-mask" metadata so the service can skip the pruned work.
+func WithFieldMasks() GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.fieldMasks = true
+	}
+}
+
+// fieldMaskOptions returns the mux options pruning responses & forwarding the requested field paths.
+func fieldMaskOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithForwardResponseOption(fieldMaskResponseModifier),
+		runtime.WithMetadata(fieldMaskMetadata),
+	}
+}
+
+// fieldMaskHandler removes the "fields" query parameter from the request and stores its paths in the request context.
+func fieldMaskHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if !query.Has(fieldsQueryParam) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var paths []string
+		for _, fields := range query[fieldsQueryParam] {
+			paths = append(paths, fieldmask.ParsePaths(fields)...)
+		}
+		query.Del(fieldsQueryParam)
+		r.URL.RawQuery = query.Encode()
+		if len(paths) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), fieldMaskKey{}, paths))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// fieldMaskPaths returns the partial response field paths stored in the context (if any).
+func fieldMaskPaths(ctx context.Context) []string {
+	paths, _ := ctx.Value(fieldMaskKey{}).([]string)
+	return paths
+}
+
+// fieldMaskMetadata forwards the requested field paths to the gRPC service.
+func fieldMaskMetadata(ctx context.Context, _ *http.Request) metadata.MD {
+	paths := fieldMaskPaths(ctx)
+	if len(paths) == 0 {
+		return nil
+	}
+	return metadata.Pairs(fieldmask.MetadataKey, strings.Join(paths, ","))
+}
+
+// fieldMaskResponseModifier prunes the response message to the requested field paths before it is marshalled.
+func fieldMaskResponseModifier(ctx context.Context, _ http.ResponseWriter, msg proto.Message) error {
+	if paths := fieldMaskPaths(ctx); len(paths) > 0 && msg != nil {
+		fieldmask.Prune(msg, paths)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldMaskRequest records what the upstream side of the test route received.
+type fieldMaskRequest struct {
+	query string
+	mask  []string
+}
+
+// setupFieldMaskGateway creates a gateway with a test route returning a file descriptor message.
+func setupFieldMaskGateway(t *testing.T, options ...GatewayOption) (http.Handler, *fieldMaskRequest) {
+	handler, mux := setupTestGateway(t, options...)
+	received := &fieldMaskRequest{}
+	handleTestPath(t, mux, http.MethodGet, "/v2/file", func(w http.ResponseWriter, r *http.Request) {
+		received.query = r.URL.RawQuery
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/test.Files/Get")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		received.mask = md.Get("x-field-mask")
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		msg := &descriptorpb.FileDescriptorProto{
+			Name:       proto.String("scanoss.proto"),
+			Package:    proto.String("scanoss.api"),
+			Dependency: []string{"common.proto"},
+			Options:    &descriptorpb.FileOptions{GoPackage: proto.String("scanoss/api"), JavaPackage: proto.String("com.scanoss")},
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, msg, mux.GetForwardResponseOptions()...)
+	})
+	return handler, received
+}
+
+func TestGatewayFieldMasks(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	full := `{"name":"scanoss.proto","package":"scanoss.api","dependency":["common.proto"],` +
+		`"options":{"javaPackage":"com.scanoss","goPackage":"scanoss/api"}}`
+	tests := []struct {
+		name     string
+		options  []GatewayOption
+		query    string
+		body     string
+		mask     []string
+		rawQuery string
+	}{
+		{name: "disabled", query: "?fields=name", body: full, rawQuery: "fields=name"},
+		{name: "no fields", options: []GatewayOption{WithFieldMasks()}, query: "?other=1", body: full, rawQuery: "other=1"},
+		{name: "fields", options: []GatewayOption{WithFieldMasks()}, query: "?fields=name,options.goPackage&other=1",
+			mask: []string{"name,options.goPackage"}, rawQuery: "other=1",
+			body: `{"name":"scanoss.proto","options":{"goPackage":"scanoss/api"}}`},
+		{name: "repeated fields", options: []GatewayOption{WithFieldMasks()}, query: "?fields=package&fields=dependency",
+			mask: []string{"package,dependency"}, body: `{"package":"scanoss.api","dependency":["common.proto"]}`},
+		{name: "empty fields", options: []GatewayOption{WithFieldMasks()}, query: "?fields=", body: full},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, received := setupFieldMaskGateway(t, tt.options...)
+			req := httptest.NewRequest(http.MethodGet, "/v2/file"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected status %v, got %v", http.StatusOK, rec.Code)
+			}
+			got, want := &descriptorpb.FileDescriptorProto{}, &descriptorpb.FileDescriptorProto{}
+			if err = protojson.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatalf("Unexpected error decoding %v: %v", rec.Body.String(), err)
+			}
+			if err = protojson.Unmarshal([]byte(tt.body), want); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("Expected body %v, got %v", tt.body, rec.Body.String())
+			}
+			if len(received.mask) != len(tt.mask) || (len(tt.mask) > 0 && received.mask[0] != tt.mask[0]) {
+				t.Errorf("Expected field mask metadata %v, got %v", tt.mask, received.mask)
+			}
+			if received.query != tt.rawQuery {
+				t.Errorf("Expected upstream query %q, got %q", tt.rawQuery, received.query)
+			}
+		})
+	}
+}
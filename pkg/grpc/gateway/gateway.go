@@ -28,10 +28,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
-	"time"
 
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -44,7 +41,8 @@ import (
 )
 
 // SetupGateway configures and returns an HTTP server that acts as a gateway to a gRPC service.
-// The gateway is forced to connect to localhost regardless of the provided grpcPort hostname.
+// The gateway is forced to connect to localhost regardless of the provided grpcPort hostname,
+// unless the WithUpstreamHost option is supplied.
 //
 // Important note about localhost and certificates:
 // The gateway always establishes its connection to the gRPC server through localhost
@@ -57,36 +55,21 @@ import (
 //     Set commonName="api.example.com" to match the certificate's subject.
 //   - If your certificate includes "localhost" in SAN:
 //     Set commonName="localhost" (or it can be left empty as "localhost" is the default).
+//
+// Optional features (i.e. OpenAPI docs, health probes, streaming) can be enabled by supplying GatewayOption values.
 func SetupGateway(grpcPort, httpPort, tlsCertFile, commonName string, allowedIPs, deniedIPs []string,
-	blockByDefault, trustProxy, startTLS bool) (*http.Server, *runtime.ServeMux, string, []grpc.DialOption, error) {
+	blockByDefault, trustProxy, startTLS bool, options ...GatewayOption) (*http.Server, *runtime.ServeMux, string, []grpc.DialOption, error) {
 	httpPort = utils.SetupPort(httpPort)
-	mux := runtime.NewServeMux(
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
-			Marshaler: &runtime.JSONPb{
-				MarshalOptions: protojson.MarshalOptions{
-					EmitDefaultValues: true,
-				},
-				UnmarshalOptions: protojson.UnmarshalOptions{
-					DiscardUnknown: true,
-				},
-			},
-		}),
-		runtime.WithForwardResponseOption(httpSuccessResponseModifier),
-		runtime.WithErrorHandler(httpErrorResponseModifier),
-	)
-	srv := &http.Server{
-		Addr:              httpPort,
-		ReadTimeout:       10 * time.Second,
-		ReadHeaderTimeout: 10 * time.Second,
-		Handler:           mux,
+	cfg := newGatewayConfig(options...)
+	if cfg.cors != nil {
+		if err := cfg.cors.validate(); err != nil {
+			zlog.S.Errorf("Invalid CORS configuration: %v", err)
+			return nil, nil, "", nil, fmt.Errorf("invalid CORS configuration: %v", err)
+		}
 	}
-	if len(allowedIPs) > 0 || len(deniedIPs) > 0 { // Configure the list of allowed/denied IPs to connect
-		zlog.S.Debugf("Filtering requests by allowed: %v, denied: %v, block-by-default: %v, trust-proxy: %v",
-			allowedIPs, deniedIPs, blockByDefault, trustProxy)
-		handler := ipfilter.Wrap(mux, ipfilter.Options{AllowedIPs: allowedIPs, BlockedIPs: deniedIPs,
-			BlockByDefault: blockByDefault, TrustProxy: trustProxy,
-		})
-		srv.Handler = handler // assign the filtered handler
+	if err := validateUploadRoutes(cfg.uploads); err != nil {
+		zlog.S.Errorf("Invalid multipart upload configuration: %v", err)
+		return nil, nil, "", nil, fmt.Errorf("invalid multipart upload configuration: %v", err)
 	}
 	var opts []grpc.DialOption
 	if startTLS {
@@ -99,12 +82,111 @@ func SetupGateway(grpcPort, httpPort, tlsCertFile, commonName string, allowedIPs
 	} else {
 		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	}
-	// force the gateway to localhost
-	var grpcGateway string
-	if strings.Contains(grpcPort, ":") { // gRPC port has a hostname in it
-		grpcGateway = "localhost:" + grpcPort[strings.LastIndex(grpcPort, ":")+1:]
-	} else {
-		grpcGateway = "localhost:" + grpcPort
+	if cfg.telemetry {
+		opts = append(opts, telemetryDialOptions()...)
+	}
+	// force the gateway to localhost (unless requested to keep the host)
+	grpcGateway := upstreamAddress(grpcPort, cfg.keepHost)
+	muxOptions := []runtime.ServeMuxOption{
+		runtime.WithForwardResponseOption(httpSuccessResponseModifier),
+		runtime.WithErrorHandler(httpErrorResponseModifier),
+	}
+	muxOptions = append(muxOptions, marshalerOptions(cfg)...)
+	muxOptions = append(muxOptions, headerMatcherOptions(cacheHeaderForwarding(cfg.headers, cfg.cache))...)
+	if cfg.fieldMasks {
+		muxOptions = append(muxOptions, fieldMaskOptions()...)
+	}
+	if cfg.telemetry {
+		muxOptions = append(muxOptions, runtime.WithMiddlewares(telemetryRouteMiddleware))
+	}
+	mux := runtime.NewServeMux(muxOptions...)
+	var handler http.Handler = mux
+	if cfg.openAPI != nil && cfg.openAPI.Enabled { // Serve the OpenAPI docs alongside the gateway routes
+		httpMux := http.NewServeMux()
+		httpMux.Handle("/", mux)
+		if err := registerOpenAPI(httpMux, cfg.openAPI); err != nil {
+			zlog.S.Errorf("Problem setting up OpenAPI docs: %v", err)
+			return nil, nil, "", nil, fmt.Errorf("failed to setup OpenAPI docs: %v", err)
+		}
+		handler = httpMux
+	}
+	if cfg.cache != nil {
+		handler = cacheHandler(handler, cfg.cache)
+	}
+	if cfg.streaming {
+		handler = streamingHandler(handler)
+	}
+	if cfg.json.AllowQueryOverride {
+		handler = jsonOptionsHandler(handler, cfg.json)
+	}
+	if cfg.fieldMasks {
+		handler = fieldMaskHandler(handler)
+	}
+	if len(cfg.uploads) > 0 {
+		handler = uploadHandler(handler, cfg.uploads)
+	}
+	var grpcWebConn *grpc.ClientConn
+	if cfg.grpcWeb { // Serve gRPC-Web calls alongside the REST routes
+		var err error
+		if grpcWebConn, err = grpc.NewClient(grpcGateway, opts...); err != nil {
+			zlog.S.Errorf("Problem setting up the gRPC-Web connection to %s: %v", grpcGateway, err)
+			return nil, nil, "", nil, fmt.Errorf("failed to setup gRPC-Web connection: %v", err)
+		}
+		handler = grpcWebHandler(handler, grpcWebConn)
+	}
+	if cfg.limits.hasBodyLimits() {
+		handler = bodyLimitHandler(handler, cfg.limits)
+	}
+	if cfg.timeouts != nil {
+		handler = timeoutHandler(handler, cfg.timeouts)
+	}
+	var probes *healthProbes
+	if cfg.health != nil && cfg.health.Enabled { // Expose the health probes
+		var err error
+		if probes, err = newHealthProbes(cfg.health, grpcGateway, opts); err != nil {
+			zlog.S.Errorf("Problem setting up health endpoints: %v", err)
+			if grpcWebConn != nil {
+				_ = grpcWebConn.Close()
+			}
+			return nil, nil, "", nil, fmt.Errorf("failed to setup health endpoints: %v", err)
+		}
+		if !cfg.health.BypassIPFilter {
+			handler = probes.wrap(handler)
+		}
+	}
+	if len(allowedIPs) > 0 || len(deniedIPs) > 0 { // Configure the list of allowed/denied IPs to connect
+		zlog.S.Debugf("Filtering requests by allowed: %v, denied: %v, block-by-default: %v, trust-proxy: %v",
+			allowedIPs, deniedIPs, blockByDefault, trustProxy)
+		handler = ipfilter.Wrap(handler, ipfilter.Options{AllowedIPs: allowedIPs, BlockedIPs: deniedIPs,
+			BlockByDefault: blockByDefault, TrustProxy: trustProxy,
+		}) // use the filtered handler
+	}
+	if probes != nil && cfg.health.BypassIPFilter {
+		handler = probes.wrap(handler)
+	}
+	if cfg.cors != nil {
+		handler = corsHandler(handler, cfg.cors, cfg.grpcWeb)
+	}
+	if cfg.security != nil {
+		handler = securityHeadersHandler(handler, cfg.security, startTLS, openAPIExplorerPath(cfg.openAPI))
+	}
+	if cfg.telemetry {
+		handler = telemetryHandler(handler)
+	}
+	srv := &http.Server{
+		Addr:    httpPort,
+		Handler: handler,
+	}
+	applyServerLimits(srv, cfg.limits)
+	if probes != nil {
+		srv.RegisterOnShutdown(probes.close)
+	}
+	if grpcWebConn != nil {
+		srv.RegisterOnShutdown(func() {
+			if err := grpcWebConn.Close(); err != nil {
+				zlog.S.Warnf("Problem closing the gRPC-Web connection: %v", err)
+			}
+		})
 	}
 	return srv, mux, grpcGateway, opts, nil
 }
@@ -110,3 +110,22 @@ func TestStartGatewayTLS(t *testing.T) {
 	}()
 	StartGateway(srv, "../../../tests/server.crt", "../../../tests/server.key", true)
 }
+
+// setupTestGateway sets up an unfiltered, non-TLS gateway with the given options for the feature tests.
+func setupTestGateway(t *testing.T, options ...GatewayOption) (http.Handler, *runtime.ServeMux) {
+	t.Helper()
+	srv, mux, _, _, err := SetupGateway("9443", "8443", "", "", nil, nil, false, false, false, options...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return srv.Handler, mux
+}
+
+// handleTestPath registers a stub upstream handler for the given method & path on the gateway mux.
+func handleTestPath(t *testing.T, mux *runtime.ServeMux, method, path string, handler func(w http.ResponseWriter, r *http.Request)) {
+	t.Helper()
+	err := mux.HandlePath(method, path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) { handler(w, r) })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
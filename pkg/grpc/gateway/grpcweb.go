@@ -0,0 +1,365 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	mimeGRPCWeb         = "application/grpc-web"
+	mimeGRPCWebText     = "application/grpc-web-text"
+	grpcWebFrameData    = byte(0x00)
+	grpcWebFrameTrailer = byte(0x80)
+	grpcWebCompressed   = byte(0x01)
+	grpcWebHeaderLength = 5
+)
+
+var (
+	// grpcWebRequestHeaders are the headers gRPC-Web clients send, which need allowing in CORS requests.
+	grpcWebRequestHeaders = []string{"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+	// grpcWebResponseHeaders are the headers gRPC-Web clients read, which need exposing in CORS responses.
+	grpcWebResponseHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+	// grpcWebSkippedHeaders are the HTTP transport, browser & proxy headers not passed between the client and the gRPC service.
+	grpcWebSkippedHeaders = map[string]bool{"accept": true, "accept-encoding": true, "connection": true, "content-length": true,
+		"content-type": true, "cookie": true, "forwarded": true, "host": true, "origin": true, "referer": true, "te": true,
+		"transfer-encoding": true, "user-agent": true, "x-grpc-web": true, "x-user-agent": true, "keep-alive": true, "upgrade": true,
+		"set-cookie": true, "trailer": true}
+)
+
+// WithGRPCWeb enables gRPC-Web (binary & text modes) on the gateway HTTP server.
+// gRPC-Web calls are forwarded to the upstream gRPC server over the same connection settings (TLS, telemetry) as the REST routes,
+// and pass through the same IP filtering, CORS, body limit & timeout handling.
+// Request headers & response metadata are passed through unprefixed (as gRPC-Web clients expect),
+// except for transport, browser, proxy & reserved (grpc-*, x-http-code) keys.
+func WithGRPCWeb() GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.grpcWeb = true
+	}
+}
+
+// rawCodec passes the already encoded protobuf messages between the gRPC-Web client and the upstream service.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// isGRPCWebRequest checks if the request is a gRPC-Web call.
+func isGRPCWebRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), mimeGRPCWeb)
+}
+
+// grpcWebHandler serves gRPC-Web calls using the given upstream connection, passing any other request on.
+func grpcWebHandler(next http.Handler, conn *grpc.ClientConn) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isGRPCWebRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		contentType := r.Header.Get("Content-Type")
+		text := strings.HasPrefix(contentType, mimeGRPCWebText)
+		if text {
+			w.Header().Set("Content-Type", mimeGRPCWebText+"+proto")
+		} else {
+			w.Header().Set("Content-Type", mimeGRPCWeb+"+proto")
+		}
+		rw := &grpcWebWriter{w: w, text: text, rc: http.NewResponseController(w)}
+		st := proxyGRPCWeb(r, conn, rw, text)
+		rw.writeTrailer(st)
+	})
+}
+
+// proxyGRPCWeb forwards the gRPC-Web call to the upstream service, streaming the responses back.
+func proxyGRPCWeb(r *http.Request, conn *grpc.ClientConn, rw *grpcWebWriter, text bool) *status.Status {
+	method := r.URL.Path
+	if strings.Count(method, "/") != 2 || !strings.HasPrefix(method, "/") {
+		return status.Newf(codes.Unimplemented, "invalid gRPC method: %s", method)
+	}
+	messages, err := readGRPCWebMessages(r.Body, text)
+	if err != nil {
+		zlog.S.Debugf("Failed to read the gRPC-Web request for %s: %v", method, err)
+		return status.Convert(err)
+	}
+	ctx, cancel, err := grpcWebContext(r)
+	if err != nil {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	defer cancel()
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	stream, err := conn.NewStream(ctx, desc, method, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return status.Convert(err)
+	}
+	for _, msg := range messages {
+		if err = stream.SendMsg(&msg); err != nil {
+			break
+		}
+	}
+	if err == nil || errors.Is(err, io.EOF) {
+		err = stream.CloseSend()
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return status.Convert(err)
+	}
+	headerSent := false
+	for {
+		var msg []byte
+		err = stream.RecvMsg(&msg)
+		if !headerSent {
+			md, _ := stream.Header()
+			rw.writeHeader(md)
+			headerSent = true
+		}
+		if err != nil {
+			break
+		}
+		if err = rw.writeFrame(grpcWebFrameData, msg); err != nil {
+			zlog.S.Debugf("Failed to write the gRPC-Web response for %s: %v", method, err)
+			return status.New(codes.Canceled, err.Error())
+		}
+	}
+	rw.trailer = stream.Trailer()
+	if errors.Is(err, io.EOF) {
+		return status.New(codes.OK, "")
+	}
+	return status.Convert(err)
+}
+
+// readGRPCWebMessages decodes the length prefixed messages of a gRPC-Web request body.
+func readGRPCWebMessages(body io.Reader, text bool) ([][]byte, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, "request body too large (limit %d bytes)", maxBytesErr.Limit)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to read request: %v", err)
+	}
+	if text {
+		if data, err = decodeGRPCWebText(data); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid gRPC-Web text request: %v", err)
+		}
+	}
+	var messages [][]byte
+	for len(data) > 0 {
+		if len(data) < grpcWebHeaderLength {
+			return nil, status.Error(codes.InvalidArgument, "truncated gRPC-Web frame header")
+		}
+		flags, length := data[0], binary.BigEndian.Uint32(data[1:grpcWebHeaderLength])
+		data = data[grpcWebHeaderLength:]
+		if uint32(len(data)) < length {
+			return nil, status.Error(codes.InvalidArgument, "truncated gRPC-Web frame")
+		}
+		if flags&grpcWebCompressed != 0 {
+			return nil, status.Error(codes.Unimplemented, "compressed gRPC-Web messages are not supported")
+		}
+		if flags&grpcWebFrameTrailer == 0 {
+			messages = append(messages, data[:length])
+		}
+		data = data[length:]
+	}
+	return messages, nil
+}
+
+// decodeGRPCWebText decodes a gRPC-Web text body, which may contain several padded base64 chunks.
+func decodeGRPCWebText(data []byte) ([]byte, error) {
+	data = bytes.Join(bytes.Fields(data), nil)
+	if len(data)%4 != 0 {
+		return nil, errors.New("base64 data is not padded")
+	}
+	decoded := make([]byte, 0, base64.StdEncoding.DecodedLen(len(data)))
+	buf := make([]byte, 3)
+	for i := 0; i < len(data); i += 4 {
+		n, err := base64.StdEncoding.Decode(buf, data[i:i+4])
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, buf[:n]...)
+	}
+	return decoded, nil
+}
+
+// grpcWebMetadataKey checks if the given header/metadata key is passed between the gRPC-Web client and the gRPC service.
+func grpcWebMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	return !grpcWebSkippedHeaders[key] && key != httpCodeMetadataKey && !strings.HasPrefix(key, "grpc-") &&
+		!strings.HasPrefix(key, "access-control-") && !strings.HasPrefix(key, "x-forwarded-") && !strings.HasPrefix(key, ":")
+}
+
+// grpcWebContext creates the upstream call context, forwarding the request headers as metadata and applying any Grpc-Timeout.
+func grpcWebContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		if !grpcWebMetadataKey(name) {
+			continue
+		}
+		key := strings.ToLower(name)
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				decoded, err := decodeBinaryHeader(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid binary header %s: %v", name, err)
+				}
+				value = string(decoded)
+			}
+			md.Append(key, value)
+		}
+	}
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+	if value := r.Header.Get(grpcTimeoutHeader); len(value) > 0 {
+		timeout, err := parseGrpcTimeout(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, cancel, nil
+}
+
+// decodeBinaryHeader decodes a base64 (padded or not) binary header value.
+func decodeBinaryHeader(value string) ([]byte, error) {
+	if len(value)%4 == 0 {
+		return base64.StdEncoding.DecodeString(value)
+	}
+	return base64.RawStdEncoding.DecodeString(value)
+}
+
+// grpcWebWriter writes the gRPC-Web response frames, base64 encoding them in text mode.
+type grpcWebWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	text    bool
+	trailer metadata.MD
+}
+
+// writeHeader exposes the upstream header metadata as HTTP headers.
+func (g *grpcWebWriter) writeHeader(md metadata.MD) {
+	for key, values := range md {
+		if !grpcWebMetadataKey(key) {
+			continue
+		}
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				value = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			g.w.Header().Add(key, value)
+		}
+	}
+}
+
+// writeFrame writes and flushes a single gRPC-Web frame.
+func (g *grpcWebWriter) writeFrame(flags byte, payload []byte) error {
+	frame := make([]byte, grpcWebHeaderLength+len(payload))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:grpcWebHeaderLength], uint32(len(payload)))
+	copy(frame[grpcWebHeaderLength:], payload)
+	if g.text {
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	if _, err := g.w.Write(frame); err != nil {
+		return err
+	}
+	if err := g.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// writeTrailer writes the final trailer frame holding the call status & trailer metadata.
+func (g *grpcWebWriter) writeTrailer(st *status.Status) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "grpc-status: %s\r\n", strconv.Itoa(int(st.Code())))
+	if len(st.Message()) > 0 {
+		fmt.Fprintf(&buf, "grpc-message: %s\r\n", encodeGrpcMessage(st.Message()))
+	}
+	if len(st.Details()) > 0 {
+		if details, err := proto.Marshal(st.Proto()); err == nil {
+			fmt.Fprintf(&buf, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(details))
+		}
+	}
+	for key, values := range g.trailer {
+		if !grpcWebMetadataKey(key) {
+			continue
+		}
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				value = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			fmt.Fprintf(&buf, "%s: %s\r\n", strings.ToLower(key), value)
+		}
+	}
+	if err := g.writeFrame(grpcWebFrameTrailer, buf.Bytes()); err != nil {
+		zlog.S.Debugf("Failed to write the gRPC-Web trailer: %v", err)
+	}
+}
+
+// encodeGrpcMessage percent encodes the status message as required by the gRPC protocol.
+func encodeGrpcMessage(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// startGRPCWebUpstream starts a gRPC health server recording the incoming metadata & returning header/trailer metadata.
+func startGRPCWebUpstream(t *testing.T) (string, *metadata.MD) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	received := &metadata.MD{}
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		*received, _ = metadata.FromIncomingContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "upstream", "x-trace-bin", "\x01\x02", "x-other", "1", "x-http-code", "200"))
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("x-elapsed", "1ms", "x-internal", "1", "x-http-code", "200"))
+		return handler(ctx, req)
+	}))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("scanoss.api", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(listen)
+	}()
+	t.Cleanup(server.Stop)
+	return fmt.Sprintf("%d", listen.Addr().(*net.TCPAddr).Port), received
+}
+
+// grpcWebRequestBody frames (and encodes in text mode) the given message as a gRPC-Web request body.
+func grpcWebRequestBody(t *testing.T, msg proto.Message, text bool) []byte {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	frame := make([]byte, grpcWebHeaderLength+len(data))
+	binary.BigEndian.PutUint32(frame[1:grpcWebHeaderLength], uint32(len(data)))
+	copy(frame[grpcWebHeaderLength:], data)
+	if text {
+		return []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	return frame
+}
+
+// parseGRPCWebResponse splits a gRPC-Web response body into its messages & trailers.
+func parseGRPCWebResponse(t *testing.T, body []byte, text bool) ([][]byte, map[string]string) {
+	var err error
+	if text {
+		if body, err = decodeGRPCWebText(body); err != nil {
+			t.Fatalf("Unexpected error decoding %q: %v", body, err)
+		}
+	}
+	var messages [][]byte
+	trailers := map[string]string{}
+	for len(body) >= grpcWebHeaderLength {
+		flags, length := body[0], binary.BigEndian.Uint32(body[1:grpcWebHeaderLength])
+		payload := body[grpcWebHeaderLength : grpcWebHeaderLength+length]
+		body = body[grpcWebHeaderLength+length:]
+		if flags&grpcWebFrameTrailer == 0 {
+			messages = append(messages, payload)
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(payload)), "\r\n") {
+			key, value, _ := strings.Cut(line, ": ")
+			trailers[key] = value
+		}
+	}
+	if len(body) > 0 {
+		t.Errorf("Unexpected trailing data in the response: %q", body)
+	}
+	return messages, trailers
+}
+
+func TestGatewayGRPCWeb(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	grpcPort, received := startGRPCWebUpstream(t)
+	srv, _, _, _, err := SetupGateway(grpcPort, "0", "", "", nil, []string{"198.51.100.7"}, false, false, false,
+		WithGRPCWeb(), WithCORS(CORSConfig{AllowedOrigins: []string{"https://scanoss.com"}}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+	tests := []struct {
+		name          string
+		path          string
+		service       string
+		text          bool
+		headers       map[string]string
+		remoteAddr    string
+		httpStatus    int
+		grpcStatus    string
+		grpcMessage   string
+		servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{name: "binary", path: "/grpc.health.v1.Health/Check", service: "scanoss.api", httpStatus: http.StatusOK, grpcStatus: "0",
+			servingStatus: grpc_health_v1.HealthCheckResponse_SERVING},
+		{name: "text", path: "/grpc.health.v1.Health/Check", service: "scanoss.api", text: true, httpStatus: http.StatusOK, grpcStatus: "0",
+			servingStatus: grpc_health_v1.HealthCheckResponse_SERVING},
+		{name: "upstream error", path: "/grpc.health.v1.Health/Check", service: "unknown", httpStatus: http.StatusOK, grpcStatus: "5",
+			grpcMessage: "unknown service"},
+		{name: "server stream", path: "/grpc.health.v1.Health/Watch", service: "scanoss.api", headers: map[string]string{"Grpc-Timeout": "200m"},
+			httpStatus: http.StatusOK, grpcStatus: "4", servingStatus: grpc_health_v1.HealthCheckResponse_SERVING},
+		{name: "unknown method", path: "/grpc.health.v1.Health/Missing", httpStatus: http.StatusOK, grpcStatus: "12"},
+		{name: "invalid method", path: "/missing", httpStatus: http.StatusOK, grpcStatus: "12"},
+		{name: "blocked", path: "/grpc.health.v1.Health/Check", remoteAddr: "198.51.100.7:1234", httpStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := grpcWebRequestBody(t, &grpc_health_v1.HealthCheckRequest{Service: tt.service}, tt.text)
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(body))
+			contentType := "application/grpc-web+proto"
+			if tt.text {
+				contentType = "application/grpc-web-text"
+			}
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("X-Grpc-Web", "1")
+			req.Header.Set("X-Api-Key", "secret")
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Cookie", "session=secret")
+			req.Header.Set("X-Forwarded-For", "203.0.113.9")
+			req.Header.Set("X-Unlisted", "1")
+			req.Header.Set("Origin", "https://scanoss.com")
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if len(tt.remoteAddr) > 0 {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			if rec.Code != tt.httpStatus {
+				t.Fatalf("Expected HTTP status %v, got %v", tt.httpStatus, rec.Code)
+			}
+			if tt.httpStatus != http.StatusOK {
+				return
+			}
+			if rec.Header().Get("Access-Control-Allow-Origin") != "https://scanoss.com" ||
+				!strings.Contains(rec.Header().Get("Access-Control-Expose-Headers"), "Grpc-Status") {
+				t.Errorf("Expected the CORS headers to expose the gRPC-Web headers, got %v", rec.Header())
+			}
+			messages, trailers := parseGRPCWebResponse(t, rec.Body.Bytes(), tt.text)
+			if trailers["grpc-status"] != tt.grpcStatus {
+				t.Errorf("Expected grpc-status %v, got %v (%v)", tt.grpcStatus, trailers["grpc-status"], trailers)
+			}
+			if len(tt.grpcMessage) > 0 && !strings.Contains(trailers["grpc-message"], tt.grpcMessage) {
+				t.Errorf("Expected grpc-message %v, got %v", tt.grpcMessage, trailers["grpc-message"])
+			}
+			if tt.servingStatus == grpc_health_v1.HealthCheckResponse_UNKNOWN {
+				return
+			}
+			if len(messages) == 0 {
+				t.Fatalf("Expected a response message, got none")
+			}
+			resp := &grpc_health_v1.HealthCheckResponse{}
+			if err = proto.Unmarshal(messages[0], resp); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.Status != tt.servingStatus {
+				t.Errorf("Expected serving status %v, got %v", tt.servingStatus, resp.Status)
+			}
+			if tt.path != "/grpc.health.v1.Health/Check" {
+				return
+			}
+			if rec.Header().Get("X-Served-By") != "upstream" || rec.Header().Get("X-Trace-Bin") != "AQI=" || rec.Header().Get("X-Other") != "1" {
+				t.Errorf("Expected the upstream header metadata, got %v", rec.Header())
+			}
+			for name := range rec.Header() {
+				if strings.HasPrefix(name, "Grpc-Metadata-") || name == "X-Http-Code" {
+					t.Errorf("Expected the header metadata to be unprefixed & x-http-code not to be exposed, got %v", rec.Header())
+				}
+			}
+			if trailers["x-elapsed"] != "1ms" || trailers["x-internal"] != "1" {
+				t.Errorf("Expected the upstream trailer metadata, got %v", trailers)
+			}
+			for name := range trailers {
+				if strings.HasPrefix(name, "grpc-trailer-") || name == "x-http-code" {
+					t.Errorf("Expected the trailer metadata to be unprefixed & x-http-code not to be exposed, got %v", trailers)
+				}
+			}
+			if strings.Join(received.Get("x-api-key"), ",") != "secret" || strings.Join(received.Get("authorization"), ",") != "Bearer token" ||
+				strings.Join(received.Get("x-unlisted"), ",") != "1" {
+				t.Errorf("Expected the application metadata to be forwarded, got %v", *received)
+			}
+			for key := range *received {
+				if strings.HasPrefix(key, "grpcgateway-") || key == "cookie" || key == "x-forwarded-for" || key == "x-grpc-web" || key == "origin" {
+					t.Errorf("Expected only unprefixed application metadata to be forwarded, got %v", *received)
+				}
+			}
+		})
+	}
+}
+
+func TestGatewayGRPCWebPassThrough(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := grpcWebHandler(next, nil)
+	req := httptest.NewRequest(http.MethodPost, "/v2/components", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !called {
+		t.Errorf("Expected REST requests to be passed on")
+	}
+}
+
+func TestReadGRPCWebMessages(t *testing.T) {
+	frame := []byte{0, 0, 0, 0, 2, 'h', 'i'}
+	tests := []struct {
+		name    string
+		body    []byte
+		text    bool
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", body: nil, want: 0},
+		{name: "single", body: frame, want: 1},
+		{name: "multiple text chunks", body: []byte(base64.StdEncoding.EncodeToString(frame) + base64.StdEncoding.EncodeToString(frame)),
+			text: true, want: 2},
+		{name: "truncated header", body: frame[:3], wantErr: true},
+		{name: "truncated frame", body: frame[:6], wantErr: true},
+		{name: "compressed", body: []byte{1, 0, 0, 0, 0}, wantErr: true},
+		{name: "invalid text", body: []byte("not base64!"), text: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := readGRPCWebMessages(bytes.NewReader(tt.body), tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(messages) != tt.want {
+				t.Errorf("Expected %v messages, got %v", tt.want, len(messages))
+			}
+		})
+	}
+	if got := encodeGrpcMessage("100% done\n"); got != "100%25 done%0A" {
+		t.Errorf("Unexpected encoded message: %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// httpCodeMetadataKey is the metadata key used by the ResponseInterceptor to tell the gateway which HTTP status to return.
+const httpCodeMetadataKey = "x-http-code"
+
+// HeaderForwarding declares which headers are passed between HTTP clients and the gRPC service.
+// Entries are case-insensitive and may end with '*' to match a prefix (i.e. "x-scanoss-*").
+// Headers not listed keep the default grpc-gateway behaviour (i.e. Grpc-Metadata-/Grpc-Trailer- prefixes).
+type HeaderForwarding struct {
+	IncomingHeaders  []string // HTTP request headers forwarded, unprefixed, as gRPC metadata (i.e. x-api-key, Accept-Language)
+	OutgoingHeaders  []string // gRPC header metadata exposed, unprefixed, as HTTP response headers
+	OutgoingTrailers []string // gRPC trailer metadata exposed, unprefixed, as HTTP response headers
+}
+
+// WithHeaderForwarding configures which headers are forwarded to and from the gRPC service.
+func WithHeaderForwarding(rules HeaderForwarding) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.headers = rules
+	}
+}
+
+// headerMatcherOptions returns the mux options implementing the given header forwarding rules.
+func headerMatcherOptions(rules HeaderForwarding) []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher(rules.IncomingHeaders)),
+		runtime.WithOutgoingHeaderMatcher(outgoingMatcher(rules.OutgoingHeaders, runtime.MetadataHeaderPrefix)),
+		runtime.WithOutgoingTrailerMatcher(outgoingMatcher(rules.OutgoingTrailers, runtime.MetadataTrailerPrefix)),
+	}
+}
+
+// incomingHeaderMatcher forwards the allowed HTTP headers as lower-case metadata keys,
+// falling back to the default grpc-gateway matcher for everything else.
+func incomingHeaderMatcher(allowed []string) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if matchHeader(allowed, key) {
+			return strings.ToLower(key), true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+// outgoingMatcher exposes the allowed metadata keys as HTTP headers, prefixing any others.
+// The internal x-http-code key is never exposed to clients.
+func outgoingMatcher(allowed []string, prefix string) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if strings.EqualFold(key, httpCodeMetadataKey) {
+			return "", false
+		}
+		if matchHeader(allowed, key) {
+			return textproto.CanonicalMIMEHeaderKey(key), true
+		}
+		return prefix + key, true
+	}
+}
+
+// matchHeader checks if the given header matches any of the (case-insensitive) allowed names or prefixes.
+func matchHeader(allowed []string, key string) bool {
+	for _, name := range allowed {
+		if prefix, found := strings.CutSuffix(name, "*"); found {
+			if len(key) >= len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+				return true
+			}
+		} else if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
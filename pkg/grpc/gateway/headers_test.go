@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestHeaderMatchers(t *testing.T) {
+	rules := HeaderForwarding{
+		IncomingHeaders:  []string{"x-api-key", "X-Request-Id", "x-scanoss-*", "Accept-Language"},
+		OutgoingHeaders:  []string{"x-request-id"},
+		OutgoingTrailers: []string{"x-scanoss-*"},
+	}
+	incoming := incomingHeaderMatcher(rules.IncomingHeaders)
+	incomingTests := []struct {
+		header string
+		key    string
+		ok     bool
+	}{
+		{header: "X-Api-Key", key: "x-api-key", ok: true},
+		{header: "X-Request-Id", key: "x-request-id", ok: true},
+		{header: "X-Scanoss-Client", key: "x-scanoss-client", ok: true},
+		{header: "Accept-Language", key: "accept-language", ok: true},
+		{header: "Grpc-Metadata-Foo", key: "Foo", ok: true},
+		{header: "Authorization", key: "grpcgateway-Authorization", ok: true},
+		{header: "X-Unknown", key: "", ok: false},
+	}
+	for _, tt := range incomingTests {
+		key, ok := incoming(tt.header)
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("Incoming %v: expected %v/%v, got %v/%v", tt.header, tt.key, tt.ok, key, ok)
+		}
+	}
+	outgoing := outgoingMatcher(rules.OutgoingHeaders, runtime.MetadataHeaderPrefix)
+	trailers := outgoingMatcher(rules.OutgoingTrailers, runtime.MetadataTrailerPrefix)
+	outgoingTests := []struct {
+		matcher runtime.HeaderMatcherFunc
+		key     string
+		header  string
+		ok      bool
+	}{
+		{matcher: outgoing, key: "x-request-id", header: "X-Request-Id", ok: true},
+		{matcher: outgoing, key: "x-other", header: "Grpc-Metadata-x-other", ok: true},
+		{matcher: outgoing, key: "x-http-code", header: "", ok: false},
+		{matcher: trailers, key: "x-scanoss-db-version", header: "X-Scanoss-Db-Version", ok: true},
+		{matcher: trailers, key: "x-other", header: "Grpc-Trailer-x-other", ok: true},
+		{matcher: trailers, key: "x-http-code", header: "", ok: false},
+	}
+	for _, tt := range outgoingTests {
+		header, ok := tt.matcher(tt.key)
+		if header != tt.header || ok != tt.ok {
+			t.Errorf("Outgoing %v: expected %v/%v, got %v/%v", tt.key, tt.header, tt.ok, header, ok)
+		}
+	}
+}
+
+func TestGatewayHeaderForwarding(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	handler, mux := setupTestGateway(t,
+		WithHeaderForwarding(HeaderForwarding{IncomingHeaders: []string{"x-api-key"}, OutgoingHeaders: []string{"x-request-id"}}))
+	var incoming metadata.MD
+	handleTestPath(t, mux, http.MethodGet, "/v2/test", func(w http.ResponseWriter, r *http.Request) {
+		ctx, annotateErr := runtime.AnnotateContext(r.Context(), mux, r, "/test.Service/Test")
+		if annotateErr != nil {
+			t.Errorf("Unexpected error: %v", annotateErr)
+		}
+		incoming, _ = metadata.FromOutgoingContext(ctx)
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{
+			HeaderMD:  metadata.Pairs("x-request-id", "1234"),
+			TrailerMD: metadata.Pairs("x-http-code", "200"),
+		})
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, &emptypb.Empty{})
+	})
+	req := httptest.NewRequest(http.MethodGet, "/v2/test", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	req.Header.Set("TE", "trailers")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if vals := incoming.Get("x-api-key"); len(vals) != 1 || vals[0] != "secret" {
+		t.Errorf("Expected x-api-key metadata to be forwarded, got %v", incoming)
+	}
+	if rec.Header().Get("X-Request-Id") != "1234" {
+		t.Errorf("Expected X-Request-Id response header, got %v", rec.Header())
+	}
+	if len(rec.Header().Get("Grpc-Trailer-X-Http-Code")) > 0 || len(rec.Header().Values("Trailer")) > 0 {
+		t.Errorf("Expected x-http-code trailer to be stripped, got %v", rec.Header())
+	}
+}
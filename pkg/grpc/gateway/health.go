@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultHealthTimeout = 2 * time.Second
+	healthStatusOK       = "ok"
+	healthStatusFail     = "fail"
+)
+
+// HealthCheck is an extra readiness dependency check (i.e. pinging the database).
+type HealthCheck struct {
+	Name  string                          // Name of the dependency reported in the readiness details
+	Check func(ctx context.Context) error // Returns an error if the dependency is not ready (required)
+}
+
+// HealthConfig controls the /healthz, /readyz and /livez probes exposed by the gateway.
+type HealthConfig struct {
+	Enabled        bool          // Expose the health probe endpoints
+	BypassIPFilter bool          // Serve the probes even to clients blocked by the IP filtering
+	Service        string        // gRPC health service name to check for readiness ("" checks the whole server)
+	Timeout        time.Duration // Timeout for each readiness check (default: 2s)
+	Checks         []HealthCheck // Extra dependencies to check for readiness
+}
+
+// WithHealthEndpoints exposes /healthz, /readyz and /livez probes on the gateway.
+func WithHealthEndpoints(config HealthConfig) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.health = &config
+	}
+}
+
+// healthCheckResult reports the state of a single dependency.
+type healthCheckResult struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// healthResponse is the JSON body returned by the health probes.
+type healthResponse struct {
+	Status string                       `json:"status"`
+	Checks map[string]healthCheckResult `json:"checks,omitempty"`
+}
+
+// healthProbes serves the health endpoints, checking the upstream gRPC server for readiness.
+type healthProbes struct {
+	config *HealthConfig
+	conn   *grpc.ClientConn
+	client grpc_health_v1.HealthClient
+}
+
+// newHealthProbes creates the health probes, using the gateway dial options to reach the upstream gRPC server.
+func newHealthProbes(config *HealthConfig, target string, opts []grpc.DialOption) (*healthProbes, error) {
+	for i, check := range config.Checks {
+		if check.Check == nil {
+			return nil, fmt.Errorf("health check %d (%s) has no Check function", i, check.Name)
+		}
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create health client for %s: %v", target, err)
+	}
+	zlog.S.Debugf("Serving health probes on /healthz, /readyz & /livez (bypass IP filter: %v)", config.BypassIPFilter)
+	return &healthProbes{config: config, conn: conn, client: grpc_health_v1.NewHealthClient(conn)}, nil
+}
+
+// wrap serves the health endpoints, passing all other requests on to the next handler.
+func (p *healthProbes) wrap(next http.Handler) http.Handler {
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/", next)
+	httpMux.HandleFunc("/healthz", p.alive)
+	httpMux.HandleFunc("/livez", p.alive)
+	httpMux.HandleFunc("/readyz", p.ready)
+	return httpMux
+}
+
+// close releases the upstream health connection.
+func (p *healthProbes) close() {
+	if err := p.conn.Close(); err != nil {
+		zlog.S.Warnf("Problem closing health client connection: %v", err)
+	}
+}
+
+// alive reports that the gateway process is up and serving requests.
+func (p *healthProbes) alive(w http.ResponseWriter, _ *http.Request) {
+	writeHealthResponse(w, healthResponse{Status: healthStatusOK})
+}
+
+// ready checks the upstream gRPC health service and any extra dependencies.
+func (p *healthProbes) ready(w http.ResponseWriter, r *http.Request) {
+	timeout := p.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	resp := healthResponse{Status: healthStatusOK, Checks: map[string]healthCheckResult{}}
+	resp.Checks["grpc"] = p.checkUpstream(r.Context(), timeout)
+	for _, check := range p.config.Checks {
+		result := healthCheckResult{Status: healthStatusOK}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		if err := check.Check(ctx); err != nil {
+			result = healthCheckResult{Status: healthStatusFail, Error: err.Error()}
+		}
+		cancel()
+		resp.Checks[check.Name] = result
+	}
+	for name, result := range resp.Checks {
+		if result.Status != healthStatusOK {
+			zlog.S.Warnf("Readiness check %s failed: %s %s", name, result.Detail, result.Error)
+			resp.Status = healthStatusFail
+		}
+	}
+	writeHealthResponse(w, resp)
+}
+
+// checkUpstream queries the gRPC health service of the upstream server.
+func (p *healthProbes) checkUpstream(ctx context.Context, timeout time.Duration) healthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := p.client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: p.config.Service})
+	if err != nil {
+		return healthCheckResult{Status: healthStatusFail, Error: err.Error()}
+	}
+	status := resp.GetStatus()
+	if status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return healthCheckResult{Status: healthStatusFail, Detail: status.String()}
+	}
+	return healthCheckResult{Status: healthStatusOK, Detail: status.String()}
+}
+
+// writeHealthResponse writes the health JSON body, returning 503 if the status is not ok.
+func writeHealthResponse(w http.ResponseWriter, resp healthResponse) {
+	code := http.StatusOK
+	if resp.Status != healthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		zlog.S.Errorf("Failed to marshal health response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
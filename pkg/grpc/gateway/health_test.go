@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer starts a local gRPC server exposing the health service.
+func startHealthServer(t *testing.T) (string, *health.Server) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(listen)
+	}()
+	t.Cleanup(server.Stop)
+	return fmt.Sprintf("%d", listen.Addr().(*net.TCPAddr).Port), healthServer
+}
+
+func TestGatewayHealthEndpoints(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	grpcPort, healthServer := startHealthServer(t)
+	dbErr := errors.New("database down")
+	var dbCheck error
+	allowedIPs := []string{"127.0.0.1"}
+	srv, _, _, _, err := SetupGateway(grpcPort, "0", "", "", allowedIPs, nil, true, false, false,
+		WithHealthEndpoints(HealthConfig{Enabled: true, BypassIPFilter: true, Checks: []HealthCheck{
+			{Name: "database", Check: func(_ context.Context) error { return dbCheck }},
+		}}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		serving    grpc_health_v1.HealthCheckResponse_ServingStatus
+		dbErr      error
+		status     int
+		body       string
+	}{
+		{name: "healthz", path: "/healthz", remoteAddr: "10.0.0.1:1234", status: http.StatusOK, body: "ok"},
+		{name: "livez", path: "/livez", remoteAddr: "10.0.0.1:1234", status: http.StatusOK, body: "ok"},
+		{name: "ready", path: "/readyz", remoteAddr: "10.0.0.1:1234", serving: grpc_health_v1.HealthCheckResponse_SERVING,
+			status: http.StatusOK, body: "ok"},
+		{name: "not serving", path: "/readyz", remoteAddr: "10.0.0.1:1234", serving: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			status: http.StatusServiceUnavailable, body: "fail"},
+		{name: "db down", path: "/readyz", remoteAddr: "10.0.0.1:1234", serving: grpc_health_v1.HealthCheckResponse_SERVING, dbErr: dbErr,
+			status: http.StatusServiceUnavailable, body: "fail"},
+		{name: "filtered", path: "/v2/other", remoteAddr: "10.0.0.1:1234", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthServer.SetServingStatus("", tt.serving)
+			dbCheck = tt.dbErr
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v - %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if len(tt.body) == 0 {
+				return
+			}
+			var resp healthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.Status != tt.body {
+				t.Errorf("Expected health status %v, got %v", tt.body, resp.Status)
+			}
+			if tt.path == "/readyz" && len(resp.Checks) != 2 {
+				t.Errorf("Expected grpc & database check details, got %v", resp.Checks)
+			}
+		})
+	}
+}
+
+func TestGatewayHealthEndpointsFiltered(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	srv, _, _, _, err := SetupGateway("9443", "0", "", "", []string{"127.0.0.1"}, nil, true, false, false,
+		WithHealthEndpoints(HealthConfig{Enabled: true}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %v, got %v", http.StatusForbidden, rec.Code)
+	}
+	req.RemoteAddr = "127.0.0.1:1234"
+	rec = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %v, got %v", http.StatusOK, rec.Code)
+	}
+}
+
+func TestGatewayHealthEndpointsInvalidCheck(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	_, _, _, _, err = SetupGateway("9443", "0", "", "", nil, nil, false, false, false,
+		WithHealthEndpoints(HealthConfig{Enabled: true, Checks: []HealthCheck{{Name: "database"}}}))
+	if err == nil {
+		t.Errorf("Expected to get an error for a health check without a Check function")
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const (
+	defaultReadTimeout       = 10 * time.Second
+	defaultReadHeaderTimeout = 10 * time.Second
+)
+
+// RouteBodyLimit overrides the maximum request body size for requests whose path starts with PathPrefix.
+type RouteBodyLimit struct {
+	PathPrefix string // URL path prefix to match (the longest matching prefix wins)
+	MaxBytes   int64  // Maximum body size in bytes (0 = unlimited)
+}
+
+// ServerLimits controls the timeouts and size limits of the gateway HTTP server.
+// Zero values keep the defaults (10s read & read header timeouts, no other limits).
+type ServerLimits struct {
+	ReadTimeout         time.Duration    // Maximum duration for reading the entire request, including the body
+	ReadHeaderTimeout   time.Duration    // Maximum duration for reading the request headers
+	WriteTimeout        time.Duration    // Maximum duration before timing out writes of the response
+	IdleTimeout         time.Duration    // Maximum time to wait for the next request when keep-alives are enabled
+	MaxHeaderBytes      int              // Maximum size of the request headers
+	MaxRequestBodyBytes int64            // Default maximum request body size in bytes (0 = unlimited)
+	RouteBodyLimits     []RouteBodyLimit // Per-route overrides of the maximum request body size
+}
+
+// WithServerLimits configures the HTTP server timeouts and request size limits of the gateway.
+func WithServerLimits(limits ServerLimits) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.limits = limits
+	}
+}
+
+// applyServerLimits sets the configured timeouts & header limits on the given HTTP server.
+func applyServerLimits(srv *http.Server, limits ServerLimits) {
+	srv.ReadTimeout = defaultReadTimeout
+	if limits.ReadTimeout > 0 {
+		srv.ReadTimeout = limits.ReadTimeout
+	}
+	srv.ReadHeaderTimeout = defaultReadHeaderTimeout
+	if limits.ReadHeaderTimeout > 0 {
+		srv.ReadHeaderTimeout = limits.ReadHeaderTimeout
+	}
+	srv.WriteTimeout = limits.WriteTimeout
+	srv.IdleTimeout = limits.IdleTimeout
+	srv.MaxHeaderBytes = limits.MaxHeaderBytes
+}
+
+// hasBodyLimits returns true if any request body size limit has been configured.
+func (l ServerLimits) hasBodyLimits() bool {
+	return l.MaxRequestBodyBytes > 0 || len(l.RouteBodyLimits) > 0
+}
+
+// bodyLimit returns the maximum request body size for the given path (0 = unlimited).
+func (l ServerLimits) bodyLimit(path string) int64 {
+	limit := l.MaxRequestBodyBytes
+	matched := -1
+	for _, route := range l.RouteBodyLimits {
+		if strings.HasPrefix(path, route.PathPrefix) && len(route.PathPrefix) > matched {
+			limit = route.MaxBytes
+			matched = len(route.PathPrefix)
+		}
+	}
+	return limit
+}
+
+// limitedBody records if the request body exceeded its http.MaxBytesReader limit.
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if err != nil && errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// bodyLimitWriter replaces the response to a request whose body exceeded its limit with a 413 error,
+// as the next handler only sees a read error (i.e. reported by grpc-gateway as a 400).
+type bodyLimitWriter struct {
+	http.ResponseWriter
+	r        *http.Request
+	body     *limitedBody
+	limit    int64
+	wrote    bool
+	rejected bool
+}
+
+func (b *bodyLimitWriter) WriteHeader(code int) {
+	if b.wrote {
+		return
+	}
+	b.wrote = true
+	if b.body.exceeded {
+		b.rejected = true
+		rejectRequestBody(b.ResponseWriter, b.r, b.limit)
+		return
+	}
+	b.ResponseWriter.WriteHeader(code)
+}
+
+func (b *bodyLimitWriter) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	if b.rejected {
+		return len(p), nil
+	}
+	return b.ResponseWriter.Write(p)
+}
+
+// Flush passes the flush on to the underlying writer (if supported).
+func (b *bodyLimitWriter) Flush() {
+	b.WriteHeader(http.StatusOK)
+	if flusher, ok := b.ResponseWriter.(http.Flusher); ok && !b.rejected {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer.
+func (b *bodyLimitWriter) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
+
+// bodyLimitHandler rejects requests whose body exceeds the configured size limit with a 413 response.
+// Bodies of unknown length (chunked) are limited while they are read, without buffering them.
+func bodyLimitHandler(next http.Handler, limits ServerLimits) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := limits.bodyLimit(r.URL.Path)
+		if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.ContentLength > limit {
+			rejectRequestBody(w, r, limit)
+			return
+		}
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, limit)}
+		r.Body = body
+		bw := &bodyLimitWriter{ResponseWriter: w, r: r, body: body, limit: limit}
+		next.ServeHTTP(bw, r)
+		if !bw.wrote && body.exceeded {
+			rejectRequestBody(w, r, limit)
+		}
+	})
+}
+
+// rejectRequestBody responds with a 413 Request Entity Too Large error.
+func rejectRequestBody(w http.ResponseWriter, r *http.Request, limit int64) {
+	zlog.S.Warnf("Rejecting request to %s from %s: body exceeds %d bytes", r.URL.Path, r.RemoteAddr, limit)
+	w.Header().Set("Connection", "close")
+	writeErrorResponse(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds the maximum size of %d bytes", limit))
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGatewaySetupServerLimits(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	srv, _, _, _, err := SetupGateway("9443", "8443", "", "", nil, nil, false, false, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if srv.ReadTimeout != 10*time.Second || srv.ReadHeaderTimeout != 10*time.Second || srv.WriteTimeout != 0 {
+		t.Errorf("Unexpected default timeouts: %v, %v, %v", srv.ReadTimeout, srv.ReadHeaderTimeout, srv.WriteTimeout)
+	}
+	limits := ServerLimits{
+		ReadTimeout:    time.Minute,
+		WriteTimeout:   2 * time.Minute,
+		IdleTimeout:    3 * time.Minute,
+		MaxHeaderBytes: 1 << 16,
+	}
+	srv, _, _, _, err = SetupGateway("9443", "8443", "", "", nil, nil, false, false, false, WithServerLimits(limits))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if srv.ReadTimeout != time.Minute || srv.ReadHeaderTimeout != 10*time.Second || srv.WriteTimeout != 2*time.Minute ||
+		srv.IdleTimeout != 3*time.Minute || srv.MaxHeaderBytes != 1<<16 {
+		t.Errorf("Unexpected server limits: %+v", srv)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	limits := ServerLimits{
+		MaxRequestBodyBytes: 10,
+		RouteBodyLimits: []RouteBodyLimit{
+			{PathPrefix: "/v2/scan", MaxBytes: 20},
+			{PathPrefix: "/v2/scan/unlimited", MaxBytes: 0},
+		},
+	}
+	tests := []struct {
+		path  string
+		limit int64
+	}{
+		{path: "/v2/components", limit: 10},
+		{path: "/v2/scan/direct", limit: 20},
+		{path: "/v2/scan/unlimited", limit: 0},
+	}
+	for _, tt := range tests {
+		if limit := limits.bodyLimit(tt.path); limit != tt.limit {
+			t.Errorf("Expected limit %v for %v, got %v", tt.limit, tt.path, limit)
+		}
+	}
+}
+
+func TestBodyLimitHandler(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength < 0 && r.URL.Path == "/silent" { // ignores the read error, without responding
+			_, _ = io.ReadAll(r.Body)
+			return
+		}
+		body, readErr := io.ReadAll(r.Body)
+		if readErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	handler := bodyLimitHandler(echo, ServerLimits{MaxRequestBodyBytes: 10, RouteBodyLimits: []RouteBodyLimit{{PathPrefix: "/big", MaxBytes: 100}}})
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		chunked bool
+		status  int
+	}{
+		{name: "small", path: "/small", body: "0123456789", status: http.StatusOK},
+		{name: "too large", path: "/small", body: "0123456789a", status: http.StatusRequestEntityTooLarge},
+		{name: "too large chunked", path: "/small", body: "0123456789a", chunked: true, status: http.StatusRequestEntityTooLarge},
+		{name: "small chunked", path: "/small", body: "0123", chunked: true, status: http.StatusOK},
+		{name: "too large chunked unread", path: "/silent", body: "0123456789a", chunked: true, status: http.StatusRequestEntityTooLarge},
+		{name: "route override", path: "/big/upload", body: strings.Repeat("a", 50), status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusOK && rec.Body.String() != tt.body {
+				t.Errorf("Expected body %v, got %v", tt.body, rec.Body.String())
+			}
+			if tt.status == http.StatusRequestEntityTooLarge {
+				var resp errorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				if resp.Status.Status != "FAILED" || len(resp.Status.Message) == 0 {
+					t.Errorf("Unexpected error response: %v", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGatewayBodyLimitChunked(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	handler, mux := setupTestGateway(t, WithServerLimits(ServerLimits{MaxRequestBodyBytes: 32}))
+	handleTestPath(t, mux, http.MethodPost, "/v2/echo", func(w http.ResponseWriter, r *http.Request) {
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+		var msg wrapperspb.StringValue
+		if decodeErr := inbound.NewDecoder(r.Body).Decode(&msg); decodeErr != nil { // as reported by the generated handlers
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "%v", decodeErr))
+			return
+		}
+		runtime.ForwardResponseMessage(r.Context(), mux, outbound, w, r, &msg)
+	})
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "small", body: `"scanoss"`, status: http.StatusOK},
+		{name: "too large", body: `"` + strings.Repeat("a", 64) + `"`, status: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v2/echo", strings.NewReader(tt.body))
+			req.ContentLength = -1 // chunked, so the limit is enforced while the upstream handler reads it
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v - %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	mimeProtobuf         = "application/x-protobuf"
+	mimeProtobufAlt      = "application/protobuf"
+	jsonNamesQueryParam  = "json_names" // per request JSON field naming: "proto" (snake_case) or "json" (camelCase)
+	enumsQueryParam      = "enums"      // per request enum rendering: "number" or "name"
+	jsonVariantMIMEFmt   = "application/x-gateway-json; proto-names=%t; enum-numbers=%t"
+	jsonNamesProto       = "proto"
+	jsonNamesJSON        = "json"
+	enumsNumber          = "number"
+	enumsName            = "name"
+	acceptHeader         = "Accept"
+	contentTypeJSONValue = "application/json"
+)
+
+// JSONOptions controls how the gateway renders JSON responses.
+type JSONOptions struct {
+	UseProtoNames      bool // Emit the original proto field names (snake_case) instead of lowerCamelCase
+	UseEnumNumbers     bool // Emit enum values as numbers instead of their names
+	AllowQueryOverride bool // Allow clients to choose per request using the json_names=proto|json & enums=number|name query parameters
+}
+
+// WithJSONOptions configures the JSON field naming and enum rendering of the gateway.
+func WithJSONOptions(options JSONOptions) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.json = options
+	}
+}
+
+// newJSONMarshaler returns the JSON marshaler used by the gateway with the given naming options.
+func newJSONMarshaler(options JSONOptions) runtime.Marshaler {
+	return &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				EmitDefaultValues: true,
+				UseProtoNames:     options.UseProtoNames,
+				UseEnumNumbers:    options.UseEnumNumbers,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		},
+	}
+}
+
+// marshalerOptions returns the mux options registering the marshalers for the enabled features.
+func marshalerOptions(cfg *gatewayConfig) []runtime.ServeMuxOption {
+	wrap := func(m runtime.Marshaler) runtime.Marshaler {
+		if cfg.streaming {
+			return &ndjsonMarshaler{Marshaler: m}
+		}
+		return m
+	}
+	jsonMarshaler := newJSONMarshaler(cfg.json)
+	protoMarshaler := &runtime.ProtoMarshaller{}
+	options := []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, wrap(jsonMarshaler)),
+		runtime.WithMarshalerOption(mimeProtobuf, protoMarshaler),
+		runtime.WithMarshalerOption(mimeProtobufAlt, protoMarshaler),
+	}
+	if cfg.json.AllowQueryOverride { // register the JSON variants selectable per request
+		for _, protoNames := range []bool{false, true} {
+			for _, enumNumbers := range []bool{false, true} {
+				variant := JSONOptions{UseProtoNames: protoNames, UseEnumNumbers: enumNumbers}
+				options = append(options, runtime.WithMarshalerOption(jsonVariantMIME(variant), wrap(newJSONMarshaler(variant))))
+			}
+		}
+	}
+	if cfg.streaming {
+		options = append(options,
+			runtime.WithMarshalerOption(mimeNDJSON, wrap(jsonMarshaler)),
+			runtime.WithMarshalerOption(mimeEventStream, &sseMarshaler{Marshaler: jsonMarshaler}),
+			runtime.WithStreamErrorHandler(streamErrorHandler),
+		)
+	}
+	return options
+}
+
+// jsonVariantMIME returns the internal MIME type used to select the JSON marshaler with the given options.
+func jsonVariantMIME(options JSONOptions) string {
+	return fmt.Sprintf(jsonVariantMIMEFmt, options.UseProtoNames, options.UseEnumNumbers)
+}
+
+// jsonOptionsHandler selects the JSON marshaler requested via the json_names & enums query parameters.
+// The parameters are removed from the query so they are not mapped onto the request message.
+func jsonOptionsHandler(next http.Handler, defaults JSONOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		names, enums := query.Get(jsonNamesQueryParam), query.Get(enumsQueryParam)
+		if len(names) == 0 && len(enums) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		options := defaults
+		switch names {
+		case jsonNamesProto:
+			options.UseProtoNames = true
+		case jsonNamesJSON:
+			options.UseProtoNames = false
+		case "":
+		default:
+			writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid %s value: %s", jsonNamesQueryParam, names))
+			return
+		}
+		switch enums {
+		case enumsNumber:
+			options.UseEnumNumbers = true
+		case enumsName:
+			options.UseEnumNumbers = false
+		case "":
+		default:
+			writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid %s value: %s", enumsQueryParam, enums))
+			return
+		}
+		query.Del(jsonNamesQueryParam)
+		query.Del(enumsQueryParam)
+		r.URL.RawQuery = query.Encode()
+		if accept := r.Header.Get(acceptHeader); len(accept) == 0 || accept == "*/*" || accept == contentTypeJSONValue {
+			r.Header.Set(acceptHeader, jsonVariantMIME(options))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// setupMarshalerGateway creates a gateway with a test route returning a message with snake_case fields & an enum.
+func setupMarshalerGateway(t *testing.T, options JSONOptions) (http.Handler, *string) {
+	handler, mux := setupTestGateway(t, WithJSONOptions(options))
+	var query string
+	handleTestPath(t, mux, http.MethodGet, "/v2/field", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		msg := &descriptorpb.FieldDescriptorProto{
+			JsonName: proto.String("field"),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, msg)
+	})
+	return handler, &query
+}
+
+func TestGatewayMarshalers(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name        string
+		options     JSONOptions
+		query       string
+		accept      string
+		status      int
+		contentType string
+		contains    []string
+		excludes    []string
+	}{
+		{name: "default json", status: http.StatusOK, contentType: "application/json",
+			contains: []string{`"jsonName":"field"`, `"type":"TYPE_STRING"`}},
+		{name: "proto names", options: JSONOptions{UseProtoNames: true, UseEnumNumbers: true}, status: http.StatusOK,
+			contains: []string{`"json_name":"field"`, `"type":9`}},
+		{name: "query ignored", query: "?json_names=proto", status: http.StatusOK,
+			contains: []string{`"jsonName":"field"`}},
+		{name: "query override", options: JSONOptions{AllowQueryOverride: true}, query: "?json_names=proto&enums=number",
+			status: http.StatusOK, contains: []string{`"json_name":"field"`, `"type":9`}},
+		{name: "query override json", options: JSONOptions{UseProtoNames: true, AllowQueryOverride: true}, query: "?json_names=json",
+			accept: "application/json", status: http.StatusOK, contains: []string{`"jsonName":"field"`, `"type":"TYPE_STRING"`}},
+		{name: "query override invalid", options: JSONOptions{AllowQueryOverride: true}, query: "?enums=roman",
+			status: http.StatusBadRequest, contains: []string{"invalid enums value"}},
+		{name: "protobuf", accept: mimeProtobuf, status: http.StatusOK, contentType: "application/octet-stream",
+			contains: []string{"field"}, excludes: []string{"jsonName"}},
+		{name: "protobuf with override", options: JSONOptions{AllowQueryOverride: true}, query: "?json_names=proto",
+			accept: mimeProtobufAlt, status: http.StatusOK, contentType: "application/octet-stream", excludes: []string{"json_name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, query := setupMarshalerGateway(t, tt.options)
+			req := httptest.NewRequest(http.MethodGet, "/v2/field"+tt.query, nil)
+			if len(tt.accept) > 0 {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v: %v", tt.status, rec.Code, rec.Body.String())
+			}
+			if len(tt.contentType) > 0 && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected content type %v, got %v", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(rec.Body.String(), c) {
+					t.Errorf("Expected body to contain %v, got %v", c, rec.Body.String())
+				}
+			}
+			for _, c := range tt.excludes {
+				if strings.Contains(rec.Body.String(), c) {
+					t.Errorf("Expected body not to contain %v, got %v", c, rec.Body.String())
+				}
+			}
+			if tt.options.AllowQueryOverride && tt.status == http.StatusOK && len(*query) > 0 {
+				t.Errorf("Expected the JSON option parameters to be removed from the query, got %v", *query)
+			}
+		})
+	}
+}
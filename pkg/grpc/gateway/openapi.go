@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const (
+	defaultOpenAPIPath  = "/openapi.json"
+	defaultExplorerPath = "/docs/"
+)
+
+// swaggerUIAssets holds the pinned swagger-ui-dist release served as the default explorer (see swagger-ui/README.md).
+//
+//go:embed swagger-ui
+var swaggerUIAssets embed.FS
+
+//go:embed explorer.html
+var explorerPage string
+
+var explorerTemplate = template.Must(template.New("explorer").Parse(explorerPage))
+
+// swaggerInitializer replaces the swagger-ui-dist initializer (which loads the Petstore demo) to load our spec instead.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %s,
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// OpenAPIDocument is a single OpenAPI (v2 or v3) JSON document to be served by the gateway.
+type OpenAPIDocument struct {
+	Name string // Name used when reporting problems with the document
+	Data []byte // Raw JSON contents of the document
+}
+
+// OpenAPIConfig controls how the gateway serves OpenAPI documents and the API explorer.
+// By default, the explorer is the Swagger UI release embedded in the gateway, pointed at the spec automatically.
+// ExplorerAssets overrides it with another copy of swagger-ui-dist (also pointed at the spec automatically)
+// or a Redoc page (whose index.html must reference the spec path).
+type OpenAPIConfig struct {
+	Enabled         bool              // Serve the spec & explorer (set to false in production to hide them)
+	Documents       []OpenAPIDocument // Documents to serve. Multiple documents are merged into one
+	SpecPath        string            // Path to serve the spec on (default: /openapi.json)
+	ExplorerPath    string            // Path to serve the explorer on (default: /docs/)
+	DisableExplorer bool              // Only serve the spec, without the explorer page
+	ExplorerAssets  fs.FS             // Static Swagger UI (swagger-ui-dist) or Redoc assets to serve instead of the embedded Swagger UI
+	Title           string            // Title of the embedded explorer page (default: API Explorer)
+}
+
+// WithOpenAPI serves the given OpenAPI documents and an offline API explorer from the gateway.
+func WithOpenAPI(config OpenAPIConfig) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.openAPI = &config
+	}
+}
+
+// LoadOpenAPIDocuments loads the given OpenAPI JSON files from disk.
+func LoadOpenAPIDocuments(filenames ...string) ([]OpenAPIDocument, error) {
+	docs := make([]OpenAPIDocument, 0, len(filenames))
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			zlog.S.Errorf("Failed to load OpenAPI document %s: %v", filename, err)
+			return nil, fmt.Errorf("failed to load OpenAPI document: %v", err)
+		}
+		docs = append(docs, OpenAPIDocument{Name: filepath.Base(filename), Data: data})
+	}
+	return docs, nil
+}
+
+// registerOpenAPI adds the OpenAPI spec & explorer handlers to the given HTTP mux.
+func registerOpenAPI(httpMux *http.ServeMux, config *OpenAPIConfig) error {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+	spec, err := mergeOpenAPIDocuments(config.Documents)
+	if err != nil {
+		return err
+	}
+	specPath := config.SpecPath
+	if len(specPath) == 0 {
+		specPath = defaultOpenAPIPath
+	}
+	httpMux.HandleFunc(specPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+	zlog.S.Debugf("Serving OpenAPI spec on %s", specPath)
+	explorerPath := openAPIExplorerPath(config)
+	if len(explorerPath) == 0 {
+		return nil
+	}
+	initializer, _ := json.Marshal(specPath)
+	initializerHandler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		_, _ = fmt.Fprintf(w, swaggerInitializer, initializer)
+	}
+	if config.ExplorerAssets != nil {
+		httpMux.Handle(explorerPath, http.StripPrefix(explorerPath, http.FileServer(http.FS(config.ExplorerAssets))))
+		if _, statErr := fs.Stat(config.ExplorerAssets, "swagger-ui-bundle.js"); statErr == nil {
+			httpMux.HandleFunc(explorerPath+"swagger-initializer.js", initializerHandler)
+		}
+		zlog.S.Debugf("Serving API explorer on %s", explorerPath)
+		return nil
+	}
+	page, err := renderExplorerPage(config.Title)
+	if err != nil {
+		return err
+	}
+	assets, err := fs.Sub(swaggerUIAssets, "swagger-ui")
+	if err != nil {
+		return fmt.Errorf("failed to load the embedded API explorer: %v", err)
+	}
+	fileServer := http.StripPrefix(explorerPath, http.FileServer(http.FS(assets)))
+	httpMux.HandleFunc(explorerPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != explorerPath {
+			fileServer.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+	httpMux.HandleFunc(explorerPath+"swagger-initializer.js", initializerHandler)
+	zlog.S.Debugf("Serving the embedded Swagger UI explorer on %s", explorerPath)
+	return nil
+}
+
+// openAPIExplorerPath returns the path the API explorer is served on, or an empty string if it is disabled.
+func openAPIExplorerPath(config *OpenAPIConfig) string {
+	if config == nil || !config.Enabled || config.DisableExplorer {
+		return ""
+	}
+	explorerPath := config.ExplorerPath
+	if len(explorerPath) == 0 {
+		explorerPath = defaultExplorerPath
+	}
+	if !strings.HasSuffix(explorerPath, "/") {
+		explorerPath += "/"
+	}
+	return explorerPath
+}
+
+// renderExplorerPage produces the index page of the embedded Swagger UI explorer.
+func renderExplorerPage(title string) ([]byte, error) {
+	if len(title) == 0 {
+		title = "API Explorer"
+	}
+	var buf bytes.Buffer
+	if err := explorerTemplate.Execute(&buf, struct{ Title string }{Title: title}); err != nil {
+		return nil, fmt.Errorf("failed to render API explorer: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeOpenAPIDocuments combines the given documents into a single spec.
+// The first document supplies the top level details (info, host, etc.), while the paths,
+// definitions, components & tags of every document are merged together.
+func mergeOpenAPIDocuments(docs []OpenAPIDocument) ([]byte, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("no OpenAPI documents supplied")
+	}
+	var merged map[string]interface{}
+	var version string
+	for _, doc := range docs {
+		var spec map[string]interface{}
+		if err := json.Unmarshal(doc.Data, &spec); err != nil {
+			zlog.S.Errorf("Failed to parse OpenAPI document %s: %v", doc.Name, err)
+			return nil, fmt.Errorf("failed to parse OpenAPI document %s: %v", doc.Name, err)
+		}
+		docVersion := openAPIMajorVersion(spec)
+		if len(docVersion) == 0 {
+			return nil, fmt.Errorf("document %s is not an OpenAPI v2/v3 document", doc.Name)
+		}
+		if merged == nil {
+			merged, version = spec, docVersion
+			continue
+		}
+		if docVersion != version {
+			return nil, fmt.Errorf("cannot merge OpenAPI v%s document %s with v%s documents", docVersion, doc.Name, version)
+		}
+		for _, key := range []string{"paths", "definitions", "securityDefinitions"} {
+			mergeOpenAPIMap(merged, spec, key)
+		}
+		if components, ok := spec["components"].(map[string]interface{}); ok {
+			mergedComponents, _ := merged["components"].(map[string]interface{})
+			if mergedComponents == nil {
+				mergedComponents = map[string]interface{}{}
+				merged["components"] = mergedComponents
+			}
+			for key := range components {
+				mergeOpenAPIMap(mergedComponents, components, key)
+			}
+		}
+		mergeOpenAPITags(merged, spec)
+	}
+	if len(docs) == 1 {
+		return docs[0].Data, nil
+	}
+	return json.Marshal(merged)
+}
+
+// openAPIMajorVersion returns the major version ("2" or "3") of the given spec, if any.
+func openAPIMajorVersion(spec map[string]interface{}) string {
+	if v, ok := spec["swagger"].(string); ok && strings.HasPrefix(v, "2") {
+		return "2"
+	}
+	if v, ok := spec["openapi"].(string); ok && strings.HasPrefix(v, "3") {
+		return "3"
+	}
+	return ""
+}
+
+// mergeOpenAPIMap copies the entries of the src object stored under key into dst.
+func mergeOpenAPIMap(dst, src map[string]interface{}, key string) {
+	srcMap, ok := src[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	dstMap, ok := dst[key].(map[string]interface{})
+	if !ok {
+		dstMap = map[string]interface{}{}
+		dst[key] = dstMap
+	}
+	for k, v := range srcMap {
+		if _, exists := dstMap[k]; exists {
+			zlog.S.Debugf("OpenAPI %s entry %s already defined. Keeping the first one", key, k)
+			continue
+		}
+		dstMap[k] = v
+	}
+}
+
+// mergeOpenAPITags appends the tags of src that are not yet defined in dst.
+func mergeOpenAPITags(dst, src map[string]interface{}) {
+	srcTags, ok := src["tags"].([]interface{})
+	if !ok {
+		return
+	}
+	dstTags, _ := dst["tags"].([]interface{})
+	known := make(map[string]bool, len(dstTags))
+	for _, tag := range dstTags {
+		if t, isMap := tag.(map[string]interface{}); isMap {
+			name, _ := t["name"].(string)
+			known[name] = true
+		}
+	}
+	for _, tag := range srcTags {
+		if t, isMap := tag.(map[string]interface{}); isMap {
+			name, _ := t["name"].(string)
+			if known[name] {
+				continue
+			}
+			known[name] = true
+		}
+		dstTags = append(dstTags, tag)
+	}
+	dst["tags"] = dstTags
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const otherOpenAPIDoc = `{"swagger": "2.0", "info": {"title": "Other", "version": "1.0"}, "tags": [{"name": "SampleService"}, {"name": "OtherService"}],
+"paths": {"/v2/other/echo": {"get": {"operationId": "OtherService_Echo"}}}, "definitions": {"otherEchoRequest": {"type": "object"}}}`
+
+func TestLoadOpenAPIDocuments(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	docs, err := LoadOpenAPIDocuments("../../../tests/openapi.swagger.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(docs) != 1 || docs[0].Name != "openapi.swagger.json" || len(docs[0].Data) == 0 {
+		t.Errorf("Unexpected documents loaded: %v", docs)
+	}
+	_, err = LoadOpenAPIDocuments("../../../tests/does-not-exist.json")
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+}
+
+func TestMergeOpenAPIDocuments(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	docs, err := LoadOpenAPIDocuments("../../../tests/openapi.swagger.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	docs = append(docs, OpenAPIDocument{Name: "other", Data: []byte(otherOpenAPIDoc)})
+	merged, err := mergeOpenAPIDocuments(docs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var spec map[string]interface{}
+	if err = json.Unmarshal(merged, &spec); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if title := spec["info"].(map[string]interface{})["title"]; title != "SCANOSS Sample Service" {
+		t.Errorf("Expected the first document info to be kept, got %v", title)
+	}
+	if paths := spec["paths"].(map[string]interface{}); len(paths) != 2 {
+		t.Errorf("Expected 2 merged paths, got %v", paths)
+	}
+	if definitions := spec["definitions"].(map[string]interface{}); len(definitions) != 2 {
+		t.Errorf("Expected 2 merged definitions, got %v", definitions)
+	}
+	if tags := spec["tags"].([]interface{}); len(tags) != 2 {
+		t.Errorf("Expected 2 merged tags, got %v", tags)
+	}
+	tests := []struct {
+		name string
+		docs []OpenAPIDocument
+	}{
+		{name: "no documents", docs: nil},
+		{name: "invalid json", docs: []OpenAPIDocument{{Name: "bad", Data: []byte("{")}}},
+		{name: "not openapi", docs: []OpenAPIDocument{{Name: "bad", Data: []byte(`{"hello": "world"}`)}}},
+		{name: "mixed versions", docs: []OpenAPIDocument{docs[0], {Name: "v3", Data: []byte(`{"openapi": "3.0.1"}`)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mergeOpenAPIDocuments(tt.docs); err == nil {
+				t.Errorf("Expected to get an error")
+			}
+		})
+	}
+}
+
+func TestGatewaySetupOpenAPI(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	docs, err := LoadOpenAPIDocuments("../../../tests/openapi.swagger.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	allowedIPs := []string{"127.0.0.1"}
+	srv, _, _, _, err := SetupGateway("9443", "8443", "", "", allowedIPs, nil, true, false, false,
+		WithOpenAPI(OpenAPIConfig{Enabled: true, Documents: docs, Title: "Sample"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		remoteAddr  string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{name: "spec", method: http.MethodGet, path: "/openapi.json", remoteAddr: "127.0.0.1:1234",
+			status: http.StatusOK, contentType: "application/json", contains: "SampleService_Echo"},
+		{name: "spec post", method: http.MethodPost, path: "/openapi.json", remoteAddr: "127.0.0.1:1234", status: http.StatusMethodNotAllowed},
+		{name: "explorer", method: http.MethodGet, path: "/docs/", remoteAddr: "127.0.0.1:1234",
+			status: http.StatusOK, contentType: "text/html; charset=utf-8", contains: "<title>Sample</title>"},
+		{name: "filtered", method: http.MethodGet, path: "/openapi.json", remoteAddr: "10.0.0.1:1234", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			if len(tt.contentType) > 0 && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected content type %v, got %v", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(rec.Body)
+			if len(tt.contains) > 0 && !strings.Contains(string(body), tt.contains) {
+				t.Errorf("Expected body to contain %v, got %s", tt.contains, body)
+			}
+		})
+	}
+	// Disabled docs should not be served
+	srv, _, _, _, err = SetupGateway("9443", "8443", "", "", nil, nil, false, false, false,
+		WithOpenAPI(OpenAPIConfig{Enabled: false, Documents: docs}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if strings.Contains(rec.Body.String(), "SampleService_Echo") {
+		t.Errorf("Expected OpenAPI spec not to be served")
+	}
+	// Invalid docs should fail the setup
+	_, _, _, _, err = SetupGateway("9443", "8443", "", "", nil, nil, false, false, false,
+		WithOpenAPI(OpenAPIConfig{Enabled: true}))
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+}
+
+func TestGatewayOpenAPIExplorerAssets(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	docs, err := LoadOpenAPIDocuments("../../../tests/openapi.swagger.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assets := fstest.MapFS{
+		"index.html":             {Data: []byte("<html>swagger-ui</html>")},
+		"swagger-ui-bundle.js":   {Data: []byte("// bundle")},
+		"swagger-initializer.js": {Data: []byte("// petstore demo")},
+	}
+	tests := []struct {
+		name     string
+		assets   fs.FS
+		path     string
+		contains string
+	}{
+		{name: "embedded", path: "/docs/", contains: `<script src="./swagger-ui-bundle.js"`},
+		{name: "embedded initializer", path: "/docs/swagger-initializer.js", contains: `url: "/api/openapi.json"`},
+		{name: "embedded bundle", path: "/docs/swagger-ui-bundle.js", contains: "SwaggerUIBundle"},
+		{name: "embedded css", path: "/docs/swagger-ui.css", contains: ".swagger-ui"},
+		{name: "swagger ui", assets: assets, path: "/docs/", contains: "swagger-ui"},
+		{name: "swagger initializer", assets: assets, path: "/docs/swagger-initializer.js", contains: `url: "/api/openapi.json"`},
+		{name: "swagger bundle", assets: assets, path: "/docs/swagger-ui-bundle.js", contains: "// bundle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, _, _, err := SetupGateway("9443", "8443", "", "", []string{"127.0.0.1"}, nil, true, false, false,
+				WithOpenAPI(OpenAPIConfig{Enabled: true, Documents: docs, SpecPath: "/api/openapi.json", ExplorerAssets: tt.assets}))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = "127.0.0.1:1234"
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected status %v, got %v", http.StatusOK, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("Expected body to contain %v, got %s", tt.contains, rec.Body.String())
+			}
+		})
+	}
+}
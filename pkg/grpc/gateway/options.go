@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+// GatewayOption configures an optional feature of the REST gateway created by SetupGateway.
+type GatewayOption func(*gatewayConfig)
+
+// gatewayConfig holds the optional settings applied by SetupGateway.
+type gatewayConfig struct {
+	openAPI    *OpenAPIConfig
+	limits     ServerLimits
+	headers    HeaderForwarding
+	health     *HealthConfig
+	streaming  bool
+	uploads    []UploadRoute
+	keepHost   bool
+	telemetry  bool
+	json       JSONOptions
+	fieldMasks bool
+	cache      *CacheConfig
+	security   *SecurityHeaders
+	timeouts   *RequestTimeouts
+	cors       *CORSConfig
+	grpcWeb    bool
+}
+
+// newGatewayConfig applies the given options on top of the default gateway settings.
+func newGatewayConfig(options ...GatewayOption) *gatewayConfig {
+	cfg := &gatewayConfig{}
+	for _, option := range options {
+		if option != nil {
+			option(cfg)
+		}
+	}
+	return cfg
+}
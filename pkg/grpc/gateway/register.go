@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// RegisterFunc matches the generated Register<Service>Handler functions (i.e. papi's RegisterScanningHandler).
+type RegisterFunc func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+// UpstreamHandlers are the service handlers served by a single upstream.
+type UpstreamHandlers struct {
+	Upstream  Upstream       // Upstream serving the services
+	Registers []RegisterFunc // Generated Register<Service>Handler functions of the services
+}
+
+// RegisterHandlers creates a single gRPC client connection to the given target (as returned by SetupGateway)
+// and registers all the supplied service handlers with the gateway mux using it.
+// It returns the handler to serve (the server's handler if srv is supplied, otherwise the mux)
+// and a function to close the shared connection once the gateway has been shut down.
+func RegisterHandlers(ctx context.Context, srv *http.Server, mux *runtime.ServeMux, target string, opts []grpc.DialOption,
+	registers ...RegisterFunc) (http.Handler, func(), error) {
+	conn, err := registerConnection(ctx, mux, target, opts, registers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gatewayHandler(srv, mux), closeConnections([]*grpc.ClientConn{conn}), nil
+}
+
+// RegisterUpstreamHandlers registers the service handlers of each upstream with the gateway mux,
+// using one shared gRPC client connection per upstream. Set telemetry if the gateway has WithTelemetry enabled.
+// It returns the handler to serve (the server's handler if srv is supplied, otherwise the mux)
+// and a function to close the connections once the gateway has been shut down.
+func RegisterUpstreamHandlers(ctx context.Context, srv *http.Server, mux *runtime.ServeMux, telemetry bool,
+	upstreams ...UpstreamHandlers) (http.Handler, func(), error) {
+	if len(upstreams) == 0 {
+		return nil, nil, fmt.Errorf("no gateway upstreams supplied to register")
+	}
+	conns := make([]*grpc.ClientConn, 0, len(upstreams))
+	for i := range upstreams {
+		target, opts, err := upstreams[i].Upstream.DialTarget(telemetry)
+		if err != nil {
+			closeConnections(conns)()
+			return nil, nil, err
+		}
+		conn, err := registerConnection(ctx, mux, target, opts, upstreams[i].Registers)
+		if err != nil {
+			closeConnections(conns)()
+			return nil, nil, fmt.Errorf("upstream %s: %v", upstreams[i].Upstream.Name, err)
+		}
+		conns = append(conns, conn)
+	}
+	return gatewayHandler(srv, mux), closeConnections(conns), nil
+}
+
+// registerConnection creates a gRPC client connection to the given target and registers the handlers using it.
+func registerConnection(ctx context.Context, mux *runtime.ServeMux, target string, opts []grpc.DialOption,
+	registers []RegisterFunc) (*grpc.ClientConn, error) {
+	if len(registers) == 0 {
+		return nil, fmt.Errorf("no gateway handlers supplied to register")
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		zlog.S.Errorf("Failed to create gRPC client for %s: %v", target, err)
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %v", target, err)
+	}
+	for i, register := range registers {
+		if err = register(ctx, mux, conn); err != nil {
+			closeConnections([]*grpc.ClientConn{conn})()
+			zlog.S.Errorf("Failed to register gateway handler %d: %v", i, err)
+			return nil, fmt.Errorf("failed to register gateway handler: %v", err)
+		}
+	}
+	zlog.S.Debugf("Registered %d gateway handler(s) against %s", len(registers), target)
+	go logConnectionState(ctx, conn, target)
+	conn.Connect()
+	return conn, nil
+}
+
+// gatewayHandler returns the server's handler if supplied, otherwise the mux.
+func gatewayHandler(srv *http.Server, mux *runtime.ServeMux) http.Handler {
+	if srv != nil && srv.Handler != nil {
+		return srv.Handler
+	}
+	return mux
+}
+
+// closeConnections returns a function closing the given connections (once).
+func closeConnections(conns []*grpc.ClientConn) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, conn := range conns {
+				if closeErr := conn.Close(); closeErr != nil {
+					zlog.S.Warnf("Problem closing gRPC gateway connection: %v", closeErr)
+				}
+			}
+		})
+	}
+}
+
+// logConnectionState logs the state changes of the gateway connection until it is closed.
+func logConnectionState(ctx context.Context, conn *grpc.ClientConn, target string) {
+	state := conn.GetState()
+	for {
+		if !conn.WaitForStateChange(ctx, state) {
+			return // context cancelled
+		}
+		state = conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			zlog.S.Infof("gRPC gateway connection to %s is ready", target)
+		case connectivity.TransientFailure:
+			zlog.S.Warnf("gRPC gateway connection to %s failed. Retrying...", target)
+		case connectivity.Shutdown:
+			zlog.S.Debugf("gRPC gateway connection to %s closed", target)
+			return
+		case connectivity.Idle, connectivity.Connecting:
+			zlog.S.Debugf("gRPC gateway connection to %s is %s", target, state)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRegisterHandlers(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	grpcPort, _ := startHealthServer(t)
+	srv, mux, gateway, opts, err := SetupGateway(grpcPort, "0", "", "", nil, nil, false, false, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var conns []*grpc.ClientConn
+	register := func(_ context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+		conns = append(conns, conn)
+		client := grpc_health_v1.NewHealthClient(conn)
+		return mux.HandlePath(http.MethodGet, fmt.Sprintf("/v2/health/%d", len(conns)), func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			resp, checkErr := client.Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+			if checkErr != nil {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(resp.GetStatus().String()))
+		})
+	}
+	handler, closeConn, err := RegisterHandlers(ctx, srv, mux, gateway, opts, register, register)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer closeConn()
+	if len(conns) != 2 || conns[0] != conns[1] {
+		t.Errorf("Expected a single shared connection, got %v", conns)
+	}
+	for _, path := range []string{"/v2/health/1", "/v2/health/2"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || rec.Body.String() != "SERVING" {
+			t.Errorf("Unexpected response from %v: %v - %v", path, rec.Code, rec.Body.String())
+		}
+	}
+	closeConn()
+	closeConn() // should be safe to call twice
+
+	_, _, err = RegisterHandlers(ctx, srv, mux, gateway, opts)
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+	failing := func(_ context.Context, _ *runtime.ServeMux, _ *grpc.ClientConn) error {
+		return errors.New("registration failed")
+	}
+	_, _, err = RegisterHandlers(ctx, nil, mux, gateway, opts, failing)
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+}
+
+func TestRegisterUpstreamHandlers(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	scanningPort, _ := startHealthServer(t)
+	cryptoPort, cryptoHealth := startHealthServer(t)
+	cryptoHealth.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	srv, mux, _, _, err := SetupGateway(scanningPort, "0", "", "", nil, nil, false, false, false, WithTelemetry())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conns := map[string][]*grpc.ClientConn{}
+	registerFor := func(name, path string) RegisterFunc {
+		return func(_ context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+			conns[name] = append(conns[name], conn)
+			client := grpc_health_v1.NewHealthClient(conn)
+			return mux.HandlePath(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				resp, checkErr := client.Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+				if checkErr != nil {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				_, _ = w.Write([]byte(resp.GetStatus().String()))
+			})
+		}
+	}
+	handler, closeConns, err := RegisterUpstreamHandlers(ctx, srv, mux, true,
+		UpstreamHandlers{
+			Upstream:  Upstream{Name: "scanning", Addresses: []string{"127.0.0.1:" + scanningPort}},
+			Registers: []RegisterFunc{registerFor("scanning", "/v2/scanning/health"), registerFor("scanning", "/v2/components/health")},
+		},
+		UpstreamHandlers{
+			Upstream:  Upstream{Name: "cryptography", Addresses: []string{"127.0.0.1:" + cryptoPort}},
+			Registers: []RegisterFunc{registerFor("cryptography", "/v2/cryptography/health")},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer closeConns()
+	if len(conns["scanning"]) != 2 || conns["scanning"][0] != conns["scanning"][1] {
+		t.Errorf("Expected a single shared scanning connection, got %v", conns["scanning"])
+	}
+	if len(conns["cryptography"]) != 1 || conns["cryptography"][0] == conns["scanning"][0] {
+		t.Errorf("Expected a separate cryptography connection, got %v", conns["cryptography"])
+	}
+	tests := map[string]string{
+		"/v2/scanning/health":     "SERVING",
+		"/v2/components/health":   "SERVING",
+		"/v2/cryptography/health": "NOT_SERVING",
+	}
+	for path, want := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || rec.Body.String() != want {
+			t.Errorf("Unexpected response from %v: %v - %v", path, rec.Code, rec.Body.String())
+		}
+	}
+	closeConns()
+	closeConns() // should be safe to call twice
+
+	_, _, err = RegisterUpstreamHandlers(ctx, srv, mux, false)
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+	_, _, err = RegisterUpstreamHandlers(ctx, srv, mux, false, UpstreamHandlers{Upstream: Upstream{Name: "empty"}})
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+	_, _, err = RegisterUpstreamHandlers(ctx, srv, mux, false, UpstreamHandlers{
+		Upstream: Upstream{Name: "no-handlers", Addresses: []string{"127.0.0.1:" + scanningPort}},
+	})
+	if err == nil {
+		t.Errorf("Expected to get an error")
+	}
+}
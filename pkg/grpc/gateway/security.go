@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHSTSMaxAge            = 365 * 24 * time.Hour
+	defaultContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+	// defaultExplorerSecurityPolicy allows the inline script & styles of the built-in API explorer page and its calls back to the gateway.
+	defaultExplorerSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; " +
+		"img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'"
+)
+
+// SecurityHeaders lists the security headers added to every gateway response. Empty values are not sent.
+type SecurityHeaders struct {
+	HSTSMaxAge                    time.Duration     // Strict-Transport-Security max-age, only sent when TLS is enabled (0 = no HSTS)
+	HSTSIncludeSubdomains         bool              // Add includeSubDomains to the Strict-Transport-Security header
+	ContentTypeOptions            string            // X-Content-Type-Options value (i.e. nosniff)
+	FrameOptions                  string            // X-Frame-Options value (i.e. DENY)
+	ContentSecurityPolicy         string            // Content-Security-Policy value for the API responses
+	ExplorerContentSecurityPolicy string            // Content-Security-Policy value for the OpenAPI explorer page
+	ReferrerPolicy                string            // Referrer-Policy value (i.e. no-referrer)
+	Custom                        map[string]string // Any additional headers to send
+}
+
+// DefaultSecurityHeaders returns the recommended set of security headers for a JSON API.
+func DefaultSecurityHeaders() SecurityHeaders {
+	return SecurityHeaders{
+		HSTSMaxAge:                    defaultHSTSMaxAge,
+		ContentTypeOptions:            "nosniff",
+		FrameOptions:                  "DENY",
+		ContentSecurityPolicy:         defaultContentSecurityPolicy,
+		ExplorerContentSecurityPolicy: defaultExplorerSecurityPolicy,
+		ReferrerPolicy:                "no-referrer",
+	}
+}
+
+// WithSecurityHeaders adds the given security headers to every gateway response (i.e. WithSecurityHeaders(DefaultSecurityHeaders())).
+func WithSecurityHeaders(headers SecurityHeaders) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.security = &headers
+	}
+}
+
+// securityHeadersHandler sets the configured security headers before passing the request on.
+// HSTS is only sent over TLS and the OpenAPI explorer page gets its own (relaxed) Content-Security-Policy.
+func securityHeadersHandler(next http.Handler, headers *SecurityHeaders, startTLS bool, explorerPath string) http.Handler {
+	var hsts string
+	if startTLS && headers.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", int64(headers.HSTSMaxAge.Seconds()))
+		if headers.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		setHeader := func(name, value string) {
+			if len(value) > 0 {
+				h.Set(name, value)
+			}
+		}
+		setHeader("Strict-Transport-Security", hsts)
+		setHeader("X-Content-Type-Options", headers.ContentTypeOptions)
+		setHeader("X-Frame-Options", headers.FrameOptions)
+		setHeader("Referrer-Policy", headers.ReferrerPolicy)
+		if len(explorerPath) > 0 && strings.HasPrefix(r.URL.Path, explorerPath) {
+			setHeader("Content-Security-Policy", headers.ExplorerContentSecurityPolicy)
+		} else {
+			setHeader("Content-Security-Policy", headers.ContentSecurityPolicy)
+		}
+		for name, value := range headers.Custom {
+			setHeader(name, value)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGatewaySecurityHeaders(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	docs, err := LoadOpenAPIDocuments("../../../tests/openapi.swagger.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	srv, _, _, _, err := SetupGateway("9443", "8443", "", "", nil, []string{"198.51.100.7"}, false, false, false,
+		WithOpenAPI(OpenAPIConfig{Enabled: true, Documents: docs}), WithSecurityHeaders(DefaultSecurityHeaders()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		status     int
+		csp        string
+	}{
+		{name: "api", path: "/v2/unknown", csp: defaultContentSecurityPolicy},
+		{name: "spec", path: "/openapi.json", status: http.StatusOK, csp: defaultContentSecurityPolicy},
+		{name: "explorer", path: "/docs/", status: http.StatusOK, csp: defaultExplorerSecurityPolicy},
+		{name: "blocked", path: "/openapi.json", remoteAddr: "198.51.100.7:1234", status: http.StatusForbidden, csp: defaultContentSecurityPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if len(tt.remoteAddr) > 0 {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			if tt.status != 0 && rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			h := rec.Header()
+			if h.Get("Content-Security-Policy") != tt.csp {
+				t.Errorf("Expected Content-Security-Policy %v, got %v", tt.csp, h.Get("Content-Security-Policy"))
+			}
+			if h.Get("X-Content-Type-Options") != "nosniff" || h.Get("X-Frame-Options") != "DENY" || h.Get("Referrer-Policy") != "no-referrer" {
+				t.Errorf("Expected the default security headers, got %v", h)
+			}
+			if len(h.Get("Strict-Transport-Security")) > 0 {
+				t.Errorf("Expected no HSTS header without TLS, got %v", h.Get("Strict-Transport-Security"))
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	tests := []struct {
+		name     string
+		headers  SecurityHeaders
+		startTLS bool
+		want     map[string]string
+	}{
+		{name: "tls defaults", headers: DefaultSecurityHeaders(), startTLS: true,
+			want: map[string]string{"Strict-Transport-Security": "max-age=31536000", "X-Frame-Options": "DENY"}},
+		{name: "tls subdomains", headers: SecurityHeaders{HSTSMaxAge: time.Hour, HSTSIncludeSubdomains: true}, startTLS: true,
+			want: map[string]string{"Strict-Transport-Security": "max-age=3600; includeSubDomains", "X-Frame-Options": ""}},
+		{name: "tls without hsts", headers: SecurityHeaders{FrameOptions: "SAMEORIGIN"}, startTLS: true,
+			want: map[string]string{"Strict-Transport-Security": "", "X-Frame-Options": "SAMEORIGIN"}},
+		{name: "custom", headers: SecurityHeaders{Custom: map[string]string{"Permissions-Policy": "camera=()"}},
+			want: map[string]string{"Permissions-Policy": "camera=()", "Content-Security-Policy": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			securityHeadersHandler(next, &tt.headers, tt.startTLS, "").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/test", nil))
+			for name, value := range tt.want {
+				if rec.Header().Get(name) != value {
+					t.Errorf("Expected %v header %q, got %q", name, value, rec.Header().Get(name))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	mimeNDJSON      = "application/x-ndjson"
+	mimeEventStream = "text/event-stream"
+)
+
+// WithStreaming renders server-streaming responses as newline-delimited JSON,
+// or as Server-Sent Events when the client sends "Accept: text/event-stream".
+func WithStreaming() GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.streaming = true
+	}
+}
+
+// streamErrorMessage is implemented by the google.rpc.Status message sent by grpc-gateway for mid-stream errors.
+type streamErrorMessage interface {
+	GetMessage() string
+}
+
+// streamError checks if v is the error chunk written by grpc-gateway when a stream fails.
+func streamError(v interface{}) (string, bool) {
+	chunk, ok := v.(map[string]proto.Message)
+	if !ok || len(chunk) != 1 {
+		return "", false
+	}
+	if st, found := chunk["error"].(streamErrorMessage); found {
+		return st.GetMessage(), true
+	}
+	return "", false
+}
+
+// marshalStreamError renders a stream error using the ResponseError JSON format.
+func marshalStreamError(message string) ([]byte, error) {
+	return json.Marshal(errorResponse{Status: statusResponse{Status: "FAILED", Message: message}})
+}
+
+// streamErrorHandler logs errors received mid-stream before they are returned to the client.
+func streamErrorHandler(ctx context.Context, err error) *status.Status {
+	zlog.S.Warnf("Error returned while streaming response: %v", err)
+	return runtime.DefaultStreamErrorHandler(ctx, err)
+}
+
+// ndjsonMarshaler renders streamed responses as newline-delimited JSON.
+type ndjsonMarshaler struct {
+	runtime.Marshaler
+}
+
+// StreamContentType returns the NDJSON content type (unless streaming raw HttpBody messages).
+func (m *ndjsonMarshaler) StreamContentType(v interface{}) string {
+	if httpBody, ok := v.(*httpbody.HttpBody); ok {
+		return httpBody.GetContentType()
+	}
+	return mimeNDJSON
+}
+
+// Delimiter returns the record separator for the stream.
+func (m *ndjsonMarshaler) Delimiter() []byte {
+	return []byte("\n")
+}
+
+// Marshal marshals v, using the ResponseError format for stream errors.
+func (m *ndjsonMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if message, ok := streamError(v); ok {
+		return marshalStreamError(message)
+	}
+	return m.Marshaler.Marshal(v)
+}
+
+// sseMarshaler renders (streamed) responses as Server-Sent Events.
+type sseMarshaler struct {
+	runtime.Marshaler
+}
+
+// ContentType returns the Server-Sent Events content type.
+func (m *sseMarshaler) ContentType(_ interface{}) string {
+	return mimeEventStream
+}
+
+// Delimiter returns an empty separator, as each marshalled event is already terminated.
+func (m *sseMarshaler) Delimiter() []byte {
+	return []byte{}
+}
+
+// Marshal renders v as a "message" event, or an "error" event for stream errors.
+func (m *sseMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if message, ok := streamError(v); ok {
+		data, err := marshalStreamError(message)
+		if err != nil {
+			return nil, err
+		}
+		return sseEvent("error", data), nil
+	}
+	if chunk, ok := v.(map[string]interface{}); ok && len(chunk) == 1 {
+		if result, found := chunk["result"]; found { // unwrap the streamed message
+			v = result
+		}
+	}
+	data, err := m.Marshaler.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return sseEvent("message", data), nil
+}
+
+// sseEvent formats the given data as a Server-Sent Event.
+func sseEvent(event string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("event: " + event + "\n")
+	for _, line := range strings.Split(string(data), "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
+
+// streamingHandler disables caching & proxy buffering for Server-Sent Event requests.
+func streamingHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == mimeEventStream {
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// setupStreamingGateway creates a gateway with a test streaming route returning the given messages and error.
+func setupStreamingGateway(t *testing.T, messages []string, streamErr error) http.Handler {
+	handler, mux := setupTestGateway(t, WithStreaming())
+	handleTestPath(t, mux, http.MethodGet, "/v2/stream", func(w http.ResponseWriter, r *http.Request) {
+		ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		i := 0
+		runtime.ForwardResponseStream(ctx, mux, outbound, w, r, func() (proto.Message, error) {
+			if i < len(messages) {
+				i++
+				return wrapperspb.String(messages[i-1]), nil
+			}
+			if streamErr != nil {
+				return nil, streamErr
+			}
+			return nil, io.EOF
+		})
+	})
+	return handler
+}
+
+func TestGatewayStreaming(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	streamErr := status.Error(codes.Internal, "lookup failed")
+	tests := []struct {
+		name        string
+		accept      string
+		messages    []string
+		err         error
+		status      int
+		contentType string
+		body        string
+	}{
+		{name: "ndjson", messages: []string{"a", "b"}, status: http.StatusOK, contentType: mimeNDJSON,
+			body: "{\"result\":\"a\"}\n{\"result\":\"b\"}\n"},
+		{name: "ndjson error", messages: []string{"a"}, err: streamErr, status: http.StatusOK, contentType: mimeNDJSON,
+			body: "{\"result\":\"a\"}\n{\"status\":{\"status\":\"FAILED\",\"message\":\"lookup failed\"}}\n"},
+		{name: "ndjson early error", err: streamErr, status: http.StatusInternalServerError,
+			body: "{\"status\":{\"status\":\"FAILED\",\"message\":\"lookup failed\"}}\n"},
+		{name: "sse", accept: mimeEventStream, messages: []string{"a", "b"}, status: http.StatusOK, contentType: mimeEventStream,
+			body: "event: message\ndata: \"a\"\n\nevent: message\ndata: \"b\"\n\n"},
+		{name: "sse error", accept: mimeEventStream, messages: []string{"a"}, err: streamErr, status: http.StatusOK, contentType: mimeEventStream,
+			body: "event: message\ndata: \"a\"\n\nevent: error\ndata: {\"status\":{\"status\":\"FAILED\",\"message\":\"lookup failed\"}}\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupStreamingGateway(t, tt.messages, tt.err)
+			req := httptest.NewRequest(http.MethodGet, "/v2/stream", nil)
+			if len(tt.accept) > 0 {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, rec.Code)
+			}
+			if len(tt.contentType) > 0 && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected content type %v, got %v", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, rec.Body.String())
+			}
+			if len(tt.messages) > 0 && !rec.Flushed {
+				t.Errorf("Expected the response to be flushed")
+			}
+			if tt.accept == mimeEventStream && rec.Header().Get("Cache-Control") != "no-cache" {
+				t.Errorf("Expected SSE responses not to be cached, got %v", rec.Header())
+			}
+		})
+	}
+}
+
+func TestSSEEvent(t *testing.T) {
+	event := string(sseEvent("message", []byte("line1\nline2")))
+	if !strings.HasPrefix(event, "event: message\n") || !strings.Contains(event, "data: line1\ndata: line2\n\n") {
+		t.Errorf("Unexpected event: %q", event)
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// WithTelemetry instruments the gateway with OpenTelemetry. HTTP server spans (named after the matched route template)
+// and request duration metrics are recorded, and the trace context is propagated to the upstream gRPC call.
+func WithTelemetry() GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.telemetry = true
+	}
+}
+
+// telemetryRouteKey is the context key holding the route template matched by the gateway mux.
+type telemetryRouteKey struct{}
+
+// telemetryHandler creates an HTTP server span & metrics for each gateway request.
+func telemetryHandler(next http.Handler) http.Handler {
+	handler := otelhttp.NewHandler(next, "gateway", otelhttp.WithSpanNameFormatter(telemetrySpanName))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var route string
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), telemetryRouteKey{}, &route)))
+	})
+}
+
+// telemetrySpanName names the server span after the matched route template (if known yet).
+func telemetrySpanName(_ string, r *http.Request) string {
+	if route, ok := r.Context().Value(telemetryRouteKey{}).(*string); ok && len(*route) > 0 {
+		return r.Method + " " + *route
+	}
+	if len(r.Pattern) > 0 && r.Pattern != "/" { // matched by the OpenAPI/health handlers
+		return r.Method + " " + r.Pattern
+	}
+	return r.Method
+}
+
+// telemetryRouteMiddleware names the server span & labels the metrics after the matched route template.
+func telemetryRouteMiddleware(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			route := strings.ReplaceAll(pattern.String(), "=*}", "}") // i.e. /v2/components/{purl}
+			if holder, found := r.Context().Value(telemetryRouteKey{}).(*string); found {
+				*holder = route
+			}
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+			if labeler, found := otelhttp.LabelerFromContext(r.Context()); found {
+				labeler.Add(semconv.HTTPRoute(route))
+			}
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// telemetryDialOptions propagates the trace context to (and traces) the upstream gRPC calls.
+func telemetryDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestGatewayTelemetry(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	defer func() {
+		_ = tracerProvider.Shutdown(context.Background())
+		_ = meterProvider.Shutdown(context.Background())
+	}()
+	srv, mux, _, opts, err := SetupGateway("9443", "8443", "", "", nil, nil, false, false, false, WithTelemetry(),
+		WithHealthEndpoints(HealthConfig{Enabled: true}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(opts) != 2 {
+		t.Errorf("Expected the otelgrpc client handler dial option, got %v", opts)
+	}
+	err = mux.HandlePath(http.MethodGet, "/v2/components/{purl}", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusOK)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/components/engine", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %v, got %v", http.StatusOK, rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	ended := spans.Ended()
+	if len(ended) != 2 || ended[0].Name() != "GET /v2/components/{purl}" || ended[1].Name() != "GET /healthz" {
+		for _, span := range ended {
+			t.Errorf("Unexpected span: %v", span.Name())
+		}
+		t.Fatalf("Expected route named spans, got %v", len(ended))
+	}
+	var metrics metricdata.ResourceMetrics
+	if err = reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	found := false
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == "http.server.request.duration" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected HTTP server duration metrics to be recorded, got %+v", metrics.ScopeMetrics)
+	}
+}
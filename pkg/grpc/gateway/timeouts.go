@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const (
+	grpcTimeoutHeader    = "Grpc-Timeout"
+	requestTimeoutHeader = "X-Request-Timeout"
+)
+
+// RequestTimeouts controls the deadline of the upstream gRPC call made for each request.
+// Clients can request a deadline using the Grpc-Timeout (i.e. 500m) or X-Request-Timeout (i.e. 2s or 2) headers.
+type RequestTimeouts struct {
+	DefaultTimeout time.Duration // Deadline applied when the client does not request one (0 = no deadline)
+	MaxTimeout     time.Duration // Maximum deadline a client can request (0 = no limit)
+}
+
+// WithRequestTimeouts enables client requested deadlines, capped by the given maximum.
+// The deadline is propagated to the gRPC service and the upstream call is cancelled if the HTTP client disconnects.
+func WithRequestTimeouts(timeouts RequestTimeouts) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.timeouts = &timeouts
+	}
+}
+
+// parseGrpcTimeout decodes a gRPC wire format timeout (i.e. 100m, 5S, 1H).
+func parseGrpcTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("invalid %s value: %s", grpcTimeoutHeader, value)
+	}
+	units := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid %s unit: %s", grpcTimeoutHeader, value)
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid %s value: %s", grpcTimeoutHeader, value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// parseRequestTimeout decodes an X-Request-Timeout value, either a Go duration (i.e. 1.5s) or a number of seconds.
+func parseRequestTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid %s value: %s", requestTimeoutHeader, value)
+	}
+	return timeout, nil
+}
+
+// requestTimeout returns the deadline to apply to the given request (0 = none).
+func requestTimeout(r *http.Request, timeouts *RequestTimeouts) (time.Duration, error) {
+	timeout := timeouts.DefaultTimeout
+	if value := r.Header.Get(grpcTimeoutHeader); len(value) > 0 {
+		requested, err := parseGrpcTimeout(value)
+		if err != nil {
+			return 0, err
+		}
+		timeout = requested
+	} else if value = r.Header.Get(requestTimeoutHeader); len(value) > 0 {
+		requested, err := parseRequestTimeout(value)
+		if err != nil {
+			return 0, err
+		}
+		timeout = requested
+	}
+	if timeouts.MaxTimeout > 0 && (timeout <= 0 || timeout > timeouts.MaxTimeout) {
+		timeout = timeouts.MaxTimeout
+	}
+	return timeout, nil
+}
+
+// timeoutWriter records if anything was written, so a timed out request can still get an error response.
+type timeoutWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (t *timeoutWriter) WriteHeader(code int) {
+	t.wrote = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *timeoutWriter) Write(b []byte) (int, error) {
+	t.wrote = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Flush passes the flush on to the underlying writer (if supported).
+func (t *timeoutWriter) Flush() {
+	t.wrote = true
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer.
+func (t *timeoutWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// timeoutHandler applies the request deadline to the request context, which is cancelled if the client disconnects.
+// The timeout headers are removed as the context deadline is forwarded to the gRPC service.
+func timeoutHandler(next http.Handler, timeouts *RequestTimeouts) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout, err := requestTimeout(r, timeouts)
+		if err != nil {
+			zlog.S.Debugf("Rejecting request for %s: %v", r.URL.Path, err)
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		r.Header.Del(grpcTimeoutHeader)
+		r.Header.Del(requestTimeoutHeader)
+		ctx := r.Context()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		tw := &timeoutWriter{ResponseWriter: w}
+		next.ServeHTTP(tw, r.WithContext(ctx))
+		switch {
+		case r.Context().Err() != nil:
+			zlog.S.Debugf("Client disconnected from %s, upstream call cancelled: %v", r.URL.Path, r.Context().Err())
+		case errors.Is(ctx.Err(), context.DeadlineExceeded) && !tw.wrote:
+			zlog.S.Debugf("Request for %s exceeded its %v deadline", r.URL.Path, timeout)
+			writeErrorResponse(w, http.StatusGatewayTimeout, "request deadline exceeded")
+		}
+	})
+}
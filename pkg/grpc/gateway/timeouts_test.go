@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// timeoutRequest records the context seen by the test routes.
+type timeoutRequest struct {
+	deadline    time.Duration
+	hasDeadline bool
+	err         error
+	grpcTimeout string
+}
+
+// setupTimeoutGateway creates a gateway with a route reporting its deadline and one waiting for its context to end.
+func setupTimeoutGateway(t *testing.T, timeouts RequestTimeouts) (http.Handler, *timeoutRequest) {
+	handler, mux := setupTestGateway(t, WithRequestTimeouts(timeouts))
+	received := &timeoutRequest{}
+	handleTestPath(t, mux, http.MethodGet, "/v2/deadline", func(w http.ResponseWriter, r *http.Request) {
+		var deadline time.Time
+		deadline, received.hasDeadline = r.Context().Deadline()
+		received.deadline = time.Until(deadline).Round(time.Second)
+		received.grpcTimeout = r.Header.Get(grpcTimeoutHeader)
+		writeErrorResponse(w, http.StatusOK, "ok")
+	})
+	handleTestPath(t, mux, http.MethodGet, "/v2/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		received.err = r.Context().Err()
+	})
+	return handler, received
+}
+
+func TestGatewayRequestTimeouts(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name     string
+		timeouts RequestTimeouts
+		headers  map[string]string
+		status   int
+		deadline time.Duration
+	}{
+		{name: "none", status: http.StatusOK},
+		{name: "default", timeouts: RequestTimeouts{DefaultTimeout: 30 * time.Second}, status: http.StatusOK, deadline: 30 * time.Second},
+		{name: "max without request", timeouts: RequestTimeouts{MaxTimeout: time.Minute}, status: http.StatusOK, deadline: time.Minute},
+		{name: "grpc timeout", timeouts: RequestTimeouts{MaxTimeout: time.Minute}, headers: map[string]string{"Grpc-Timeout": "20S"},
+			status: http.StatusOK, deadline: 20 * time.Second},
+		{name: "request timeout seconds", headers: map[string]string{"X-Request-Timeout": "15"}, status: http.StatusOK, deadline: 15 * time.Second},
+		{name: "request timeout duration", headers: map[string]string{"X-Request-Timeout": "1m30s"}, status: http.StatusOK, deadline: 90 * time.Second},
+		{name: "capped", timeouts: RequestTimeouts{MaxTimeout: 10 * time.Second}, headers: map[string]string{"X-Request-Timeout": "1h"},
+			status: http.StatusOK, deadline: 10 * time.Second},
+		{name: "invalid grpc timeout", headers: map[string]string{"Grpc-Timeout": "10x"}, status: http.StatusBadRequest},
+		{name: "invalid request timeout", headers: map[string]string{"X-Request-Timeout": "soon"}, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, received := setupTimeoutGateway(t, tt.timeouts)
+			req := httptest.NewRequest(http.MethodGet, "/v2/deadline", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v: %v", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if received.hasDeadline != (tt.deadline > 0) || (tt.deadline > 0 && received.deadline != tt.deadline) {
+				t.Errorf("Expected deadline %v, got %v (%v)", tt.deadline, received.deadline, received.hasDeadline)
+			}
+			if len(received.grpcTimeout) > 0 {
+				t.Errorf("Expected the timeout header to be removed, got %v", received.grpcTimeout)
+			}
+		})
+	}
+}
+
+func TestGatewayRequestTimeoutCancellation(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	handler, received := setupTimeoutGateway(t, RequestTimeouts{MaxTimeout: time.Minute})
+	// Deadline exceeded
+	req := httptest.NewRequest(http.MethodGet, "/v2/slow", nil)
+	req.Header.Set("Grpc-Timeout", "50m")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected status %v, got %v", http.StatusGatewayTimeout, rec.Code)
+	}
+	if received.err != context.DeadlineExceeded {
+		t.Errorf("Expected the upstream context to exceed its deadline, got %v", received.err)
+	}
+	// Client disconnect
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req = httptest.NewRequest(http.MethodGet, "/v2/slow", nil).WithContext(ctx)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if received.err != context.Canceled {
+		t.Errorf("Expected the upstream context to be cancelled, got %v", received.err)
+	}
+	if rec.Body.Len() > 0 {
+		t.Errorf("Expected no response for a disconnected client, got %v", rec.Body.String())
+	}
+}
+
+func TestParseGrpcTimeout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "1H", want: time.Hour},
+		{value: "2M", want: 2 * time.Minute},
+		{value: "3S", want: 3 * time.Second},
+		{value: "400m", want: 400 * time.Millisecond},
+		{value: "5u", want: 5 * time.Microsecond},
+		{value: "6n", want: 6 * time.Nanosecond},
+		{value: "S", wantErr: true},
+		{value: "123456789S", wantErr: true},
+		{value: "-1S", wantErr: true},
+		{value: "1s", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseGrpcTimeout(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseGrpcTimeout(%q) = %v, %v, want %v (error: %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	mimeMultipartForm            = "multipart/form-data"
+	defaultUploadRequestSize     = 32 << 20 // 32 MB maximum upload request size
+	defaultUploadMemoryThreshold = 1 << 20  // 1 MB of a file held in memory before it is spooled to disk
+	base64ChunkSize              = 3 << 10  // raw bytes encoded per base64 chunk (a multiple of 3, so only the last chunk is padded)
+)
+
+// UploadRoute maps multipart/form-data uploads for a gateway route onto its gRPC request message.
+// File parts and form fields are matched to message fields by their proto or JSON name, unless mapped explicitly.
+// File parts for bytes fields larger than MemoryThreshold are spooled to temporary files (removed after the request),
+// and streamed base64 encoded into the JSON request passed on to the gateway.
+// The gateway still decodes the final gRPC request message in memory, so MaxRequestSize (default: 32 MB) bounds the memory
+// used by each upload. Use a client streaming gRPC method for files that should not be held in memory at all.
+type UploadRoute struct {
+	Method              string            // HTTP method of the route (default: POST)
+	Path                string            // HTTP path of the route (exact match)
+	Message             proto.Message     // Request message of the route (required, used as a template)
+	FileFields          map[string]string // Optional mapping of file part names to message field names
+	FormFields          map[string]string // Optional mapping of form field names to message field names
+	MaxFileSize         int64             // Maximum size of a single file in bytes, checked while reading (0 = request limit only)
+	MaxRequestSize      int64             // Maximum size of the whole request in bytes (default: 32 MB)
+	MemoryThreshold     int64             // Size in bytes above which files are spooled to a temporary file (default: 1 MB)
+	AllowedContentTypes []string          // Allowed file content types (i.e. "text/plain", "application/*"). Empty allows all
+}
+
+// WithMultipartUploads accepts multipart/form-data uploads on the given gateway routes.
+func WithMultipartUploads(routes ...UploadRoute) GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.uploads = append(cfg.uploads, routes...)
+	}
+}
+
+// validateUploadRoutes checks that every upload route has a request message to convert the uploads into.
+func validateUploadRoutes(routes []UploadRoute) error {
+	for _, route := range routes {
+		if route.Message == nil {
+			return fmt.Errorf("no request message supplied for upload route %s", route.Path)
+		}
+	}
+	return nil
+}
+
+// uploadError is a multipart request problem reported to the client with the given HTTP status.
+type uploadError struct {
+	code    int
+	message string
+}
+
+// Error implements the error interface.
+func (e *uploadError) Error() string {
+	return e.message
+}
+
+// uploadHandler converts multipart/form-data requests on the configured routes into JSON requests for the gateway mux.
+func uploadHandler(next http.Handler, routes []UploadRoute) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != mimeMultipartForm {
+			next.ServeHTTP(w, r)
+			return
+		}
+		route := findUploadRoute(routes, r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		upload, err := route.convert(w, r)
+		if err != nil {
+			var upErr *uploadError
+			if !errors.As(err, &upErr) {
+				upErr = &uploadError{code: http.StatusBadRequest, message: "failed to process multipart request"}
+			}
+			zlog.S.Warnf("Rejecting upload to %s: %v", r.URL.Path, err)
+			writeErrorResponse(w, upErr.code, upErr.message)
+			return
+		}
+		defer upload.cleanup()
+		body, length, err := upload.reader()
+		if err != nil {
+			zlog.S.Errorf("Failed to encode upload to %s: %v", r.URL.Path, err)
+			writeErrorResponse(w, http.StatusInternalServerError, "failed to process multipart request")
+			return
+		}
+		r.Body = io.NopCloser(body)
+		r.ContentLength = length
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Content-Length", strconv.FormatInt(length, 10))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// findUploadRoute returns the upload route matching the given request, if any.
+func findUploadRoute(routes []UploadRoute, r *http.Request) *UploadRoute {
+	for i := range routes {
+		method := routes[i].Method
+		if len(method) == 0 {
+			method = http.MethodPost
+		}
+		if method == r.Method && routes[i].Path == r.URL.Path {
+			return &routes[i]
+		}
+	}
+	return nil
+}
+
+// convert streams the multipart request into the equivalent request message.
+// The caller must clean up the returned upload once the request has been handled.
+func (u *UploadRoute) convert(w http.ResponseWriter, r *http.Request) (*uploadBody, error) {
+	maxRequestSize := u.MaxRequestSize
+	if maxRequestSize <= 0 {
+		maxRequestSize = defaultUploadRequestSize
+	}
+	tooLarge := &uploadError{code: http.StatusRequestEntityTooLarge, message: fmt.Sprintf("request exceeds the maximum size of %d bytes", maxRequestSize)}
+	if r.ContentLength > maxRequestSize {
+		return nil, tooLarge
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("invalid multipart request: %v", err)}
+	}
+	upload := newUploadBody(u.Message.ProtoReflect().New())
+	if err = u.readParts(reader, upload, tooLarge); err != nil {
+		upload.cleanup()
+		return nil, err
+	}
+	return upload, nil
+}
+
+// readParts reads the parts of the multipart request into the upload.
+func (u *UploadRoute) readParts(reader *multipart.Reader, upload *uploadBody, tooLarge error) error {
+	for {
+		part, partErr := reader.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			break
+		}
+		if partErr != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(partErr, &maxBytesErr) {
+				return tooLarge
+			}
+			return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("invalid multipart request: %v", partErr)}
+		}
+		if err := u.convertPart(upload, part, tooLarge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertPart sets the message field matching the given form field or file part.
+func (u *UploadRoute) convertPart(upload *uploadBody, part *multipart.Part, tooLarge error) error {
+	defer func() {
+		_ = part.Close()
+	}()
+	msg := upload.msg
+	name := part.FormName()
+	if len(part.FileName()) == 0 {
+		field := findUploadField(msg.Descriptor(), name, u.FormFields)
+		if field == nil {
+			return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("unknown form field: %s", name)}
+		}
+		value, err := readUploadPart(part, 0, tooLarge)
+		if err != nil {
+			return err
+		}
+		if field.Kind() == protoreflect.BytesKind {
+			upload.add(field, &uploadValue{data: value, size: int64(len(value))})
+			return nil
+		}
+		if err = setUploadField(msg, field, value); err != nil {
+			return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("invalid value for form field %s: %v", name, err)}
+		}
+		return nil
+	}
+	field := findUploadField(msg.Descriptor(), name, u.FileFields)
+	if field == nil || (field.Kind() != protoreflect.BytesKind && field.Kind() != protoreflect.StringKind) {
+		return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("unexpected file part: %s", name)}
+	}
+	contentType := part.Header.Get("Content-Type")
+	if !allowedContentType(u.AllowedContentTypes, contentType) {
+		return &uploadError{code: http.StatusUnsupportedMediaType, message: fmt.Sprintf("file %s has an unsupported content type: %s", part.FileName(), contentType)}
+	}
+	fileTooLarge := &uploadError{code: http.StatusRequestEntityTooLarge, message: fmt.Sprintf("file %s exceeds the maximum size of %d bytes", part.FileName(), u.MaxFileSize)}
+	if field.Kind() == protoreflect.BytesKind {
+		threshold := u.MemoryThreshold
+		if threshold <= 0 {
+			threshold = defaultUploadMemoryThreshold
+		}
+		value, err := upload.spoolPart(part, u.MaxFileSize, threshold, tooLarge)
+		if errors.Is(err, errPartTooLarge) {
+			return fileTooLarge
+		}
+		if err != nil {
+			return err
+		}
+		upload.add(field, value)
+		return nil
+	}
+	data, err := readUploadPart(part, u.MaxFileSize, tooLarge)
+	if errors.Is(err, errPartTooLarge) {
+		return fileTooLarge
+	}
+	if err != nil {
+		return err
+	}
+	if err = setUploadField(msg, field, data); err != nil {
+		return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("invalid file part %s: %v", name, err)}
+	}
+	return nil
+}
+
+// errPartTooLarge is returned when a part exceeds its size limit.
+var errPartTooLarge = errors.New("part too large")
+
+// readUploadPart reads the given part into memory, stopping as soon as it exceeds maxSize (0 = only the request limit applies).
+func readUploadPart(part *multipart.Part, maxSize int64, tooLarge error) ([]byte, error) {
+	var reader io.Reader = part
+	if maxSize > 0 {
+		reader = io.LimitReader(part, maxSize+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, partReadError(part, err, tooLarge)
+	}
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return nil, errPartTooLarge
+	}
+	return data, nil
+}
+
+// partReadError converts a failure reading the given part into the upload error reported to the client.
+func partReadError(part *multipart.Part, err error, tooLarge error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return tooLarge
+	}
+	return &uploadError{code: http.StatusBadRequest, message: fmt.Sprintf("failed to read part %s: %v", part.FormName(), err)}
+}
+
+// allowedContentType checks the content type against the allowed list (supporting "type/*" wildcards).
+func allowedContentType(allowed []string, contentType string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream" // parts without a content type are treated as binary
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, mediaType) {
+			return true
+		}
+		if prefix, found := strings.CutSuffix(a, "/*"); found && strings.HasPrefix(strings.ToLower(mediaType), strings.ToLower(prefix)+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// findUploadField finds the message field for the given part name, honouring any explicit mapping.
+func findUploadField(desc protoreflect.MessageDescriptor, name string, mapping map[string]string) protoreflect.FieldDescriptor {
+	if mapped, ok := mapping[name]; ok {
+		name = mapped
+	}
+	if field := desc.Fields().ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return desc.Fields().ByJSONName(name)
+}
+
+// setUploadField sets (or appends to) the given scalar field from the uploaded value.
+func setUploadField(msg protoreflect.Message, field protoreflect.FieldDescriptor, data []byte) error {
+	if field.IsMap() || field.Message() != nil {
+		return fmt.Errorf("field %s is not a scalar", field.Name())
+	}
+	value, err := parseUploadValue(field, data)
+	if err != nil {
+		return err
+	}
+	if field.IsList() {
+		msg.Mutable(field).List().Append(value)
+	} else {
+		msg.Set(field, value)
+	}
+	return nil
+}
+
+// parseUploadValue converts the uploaded data into a value for the given field kind.
+func parseUploadValue(field protoreflect.FieldDescriptor, data []byte) (protoreflect.Value, error) {
+	text := strings.TrimSpace(string(data))
+	switch field.Kind() { //nolint:exhaustive // message & group kinds are rejected by the caller
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(data), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(string(data)), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(text)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(text, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(text, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(text, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(text, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(text, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(text)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		v, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field type %s", field.Kind())
+	}
+}
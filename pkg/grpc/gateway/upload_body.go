@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// uploadValue is an uploaded bytes field value, held in memory or spooled to a temporary file.
+type uploadValue struct {
+	data []byte
+	file *os.File
+	size int64
+}
+
+// reader returns a reader over the value, from the start.
+func (v *uploadValue) reader() (io.Reader, error) {
+	if v.file == nil {
+		return bytes.NewReader(v.data), nil
+	}
+	if _, err := v.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return v.file, nil
+}
+
+// uploadBody is a converted upload: the request message, plus its bytes fields held apart so they can be streamed.
+type uploadBody struct {
+	msg    protoreflect.Message
+	fields []protoreflect.FieldDescriptor // bytes fields, in the order they were uploaded
+	values map[protoreflect.FieldNumber][]*uploadValue
+	files  []*os.File
+}
+
+// newUploadBody creates an empty upload for the given request message.
+func newUploadBody(msg protoreflect.Message) *uploadBody {
+	return &uploadBody{msg: msg, values: map[protoreflect.FieldNumber][]*uploadValue{}}
+}
+
+// add sets (or appends to, for repeated fields) the given bytes field.
+func (b *uploadBody) add(field protoreflect.FieldDescriptor, value *uploadValue) {
+	values, ok := b.values[field.Number()]
+	if !ok {
+		b.fields = append(b.fields, field)
+	}
+	if field.IsList() {
+		b.values[field.Number()] = append(values, value)
+	} else {
+		b.values[field.Number()] = []*uploadValue{value}
+	}
+}
+
+// spoolPart reads the given part, spooling it to a temporary file once it exceeds the memory threshold.
+// It stops as soon as the part exceeds maxSize (0 = only the request limit applies).
+func (b *uploadBody) spoolPart(part *multipart.Part, maxSize, threshold int64, tooLarge error) (*uploadValue, error) {
+	var reader io.Reader = part
+	if maxSize > 0 {
+		reader = io.LimitReader(part, maxSize+1)
+	}
+	data, err := io.ReadAll(io.LimitReader(reader, threshold+1))
+	if err != nil {
+		return nil, partReadError(part, err, tooLarge)
+	}
+	value := &uploadValue{data: data, size: int64(len(data))}
+	if value.size > threshold {
+		file, fileErr := os.CreateTemp("", "gateway-upload-*")
+		if fileErr != nil {
+			zlog.S.Errorf("Failed to create a temporary file for upload part %s: %v", part.FormName(), fileErr)
+			return nil, &uploadError{code: http.StatusInternalServerError, message: "failed to store the uploaded file"}
+		}
+		b.files = append(b.files, file)
+		value = &uploadValue{file: file}
+		if value.size, err = io.Copy(file, io.MultiReader(bytes.NewReader(data), reader)); err != nil {
+			var pathErr *os.PathError
+			if errors.As(err, &pathErr) {
+				zlog.S.Errorf("Failed to spool upload part %s to %s: %v", part.FormName(), file.Name(), err)
+				return nil, &uploadError{code: http.StatusInternalServerError, message: "failed to store the uploaded file"}
+			}
+			return nil, partReadError(part, err, tooLarge)
+		}
+	}
+	if maxSize > 0 && value.size > maxSize {
+		return nil, errPartTooLarge
+	}
+	return value, nil
+}
+
+// reader returns the JSON encoded request message and its length, streaming the bytes fields base64 encoded.
+func (b *uploadBody) reader() (io.Reader, int64, error) {
+	head, err := protojson.Marshal(b.msg.Interface())
+	if err != nil {
+		return nil, 0, err
+	}
+	head = bytes.TrimSpace(head)
+	if !bytes.HasPrefix(head, []byte("{")) || !bytes.HasSuffix(head, []byte("}")) {
+		return b.materialize() // not encoded as a JSON object (i.e. a well-known type), so cannot be streamed
+	}
+	head = bytes.TrimSpace(head[:len(head)-1]) // drop the closing brace, to append the bytes fields
+	readers := []io.Reader{bytes.NewReader(head)}
+	length := int64(len(head))
+	appendText := func(text string) {
+		readers = append(readers, strings.NewReader(text))
+		length += int64(len(text))
+	}
+	separator := ","
+	if len(head) == 1 { // no other fields set
+		separator = ""
+	}
+	for _, field := range b.fields {
+		appendText(fmt.Sprintf(`%s"%s":`, separator, field.JSONName()))
+		separator = ","
+		if field.IsList() {
+			appendText("[")
+		}
+		for i, value := range b.values[field.Number()] {
+			if i > 0 {
+				appendText(",")
+			}
+			data, readErr := value.reader()
+			if readErr != nil {
+				return nil, 0, readErr
+			}
+			appendText(`"`)
+			readers = append(readers, newBase64Reader(data))
+			length += int64(base64.StdEncoding.EncodedLen(int(value.size)))
+			appendText(`"`)
+		}
+		if field.IsList() {
+			appendText("]")
+		}
+	}
+	appendText("}")
+	return io.MultiReader(readers...), length, nil
+}
+
+// materialize sets the bytes fields on the message and returns it JSON encoded in memory.
+func (b *uploadBody) materialize() (io.Reader, int64, error) {
+	msg := b.msg.Interface()
+	for _, field := range b.fields {
+		for _, value := range b.values[field.Number()] {
+			data, err := value.reader()
+			if err != nil {
+				return nil, 0, err
+			}
+			raw, err := io.ReadAll(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			if err = setUploadField(b.msg, field, raw); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	body, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(body), int64(len(body)), nil
+}
+
+// cleanup closes & removes any temporary files.
+func (b *uploadBody) cleanup() {
+	for _, file := range b.files {
+		_ = file.Close()
+		if err := os.Remove(file.Name()); err != nil {
+			zlog.S.Warnf("Failed to remove temporary upload file %s: %v", file.Name(), err)
+		}
+	}
+	b.files = nil
+}
+
+// base64Reader base64 encodes (standard, padded) the data read from src.
+type base64Reader struct {
+	src     io.Reader
+	raw     []byte
+	encoded []byte
+	pending []byte
+	done    bool
+}
+
+// newBase64Reader creates a reader base64 encoding src.
+func newBase64Reader(src io.Reader) *base64Reader {
+	return &base64Reader{src: src, raw: make([]byte, base64ChunkSize), encoded: make([]byte, base64.StdEncoding.EncodedLen(base64ChunkSize))}
+}
+
+// Read implements io.Reader, encoding a chunk of src at a time.
+func (b *base64Reader) Read(p []byte) (int, error) {
+	for len(b.pending) == 0 {
+		if b.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(b.src, b.raw)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			b.done = true
+		} else if err != nil {
+			return 0, err
+		}
+		b.pending = b.encoded[:base64.StdEncoding.EncodedLen(n)]
+		base64.StdEncoding.Encode(b.pending, b.raw[:n])
+	}
+	n := copy(p, b.pending)
+	b.pending = b.pending[n:]
+	return n, nil
+}
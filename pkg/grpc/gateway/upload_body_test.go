@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestBase64Reader(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, base64ChunkSize - 1, base64ChunkSize, base64ChunkSize + 1, 3*base64ChunkSize + 2} {
+		data := bytes.Repeat([]byte{0xfb, 0x01, 0x7f}, size/3+1)[:size]
+		encoded, err := io.ReadAll(newBase64Reader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := base64.StdEncoding.EncodeToString(data); string(encoded) != want {
+			t.Errorf("Unexpected encoding of %d bytes: %q, want %q", size, encoded, want)
+		}
+	}
+}
+
+func TestUploadBodyReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    proto.Message
+		field  string
+		values [][]byte
+	}{
+		{name: "no bytes fields", msg: &httpbody.HttpBody{ContentType: "text/plain"}},
+		{name: "well-known type", msg: &wrapperspb.BytesValue{}, field: "value", values: [][]byte{[]byte("file=1234")}},
+		{name: "with other fields", msg: &httpbody.HttpBody{ContentType: "text/plain"}, field: "data", values: [][]byte{[]byte("old"), []byte("new")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload := newUploadBody(proto.Clone(tt.msg).ProtoReflect())
+			want := proto.Clone(tt.msg)
+			for _, value := range tt.values {
+				field := upload.msg.Descriptor().Fields().ByName(protoreflect.Name(tt.field))
+				upload.add(field, &uploadValue{data: value, size: int64(len(value))})
+				want.ProtoReflect().Set(field, protoreflect.ValueOfBytes(value))
+			}
+			body, length, err := upload.reader()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if int64(len(data)) != length {
+				t.Errorf("Expected a body of %d bytes, got %d: %s", length, len(data), data)
+			}
+			got := want.ProtoReflect().New().Interface()
+			if err = protojson.Unmarshal(data, got); err != nil {
+				t.Fatalf("Unexpected error decoding %s: %v", data, err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestGatewayMultipartUploadSpooling(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	route := UploadRoute{Path: "/v2/upload", Message: &httpbody.HttpBody{}, FileFields: map[string]string{"file": "data"},
+		FormFields: map[string]string{"type": "content_type"}, MemoryThreshold: 16}
+	handler, mux := setupTestGateway(t, WithMultipartUploads(route))
+	var received httpbody.HttpBody
+	var spooled int
+	handleTestPath(t, mux, http.MethodPost, "/v2/upload", func(w http.ResponseWriter, r *http.Request) {
+		entries, _ := os.ReadDir(tmpDir)
+		spooled = len(entries)
+		inbound, _ := runtime.MarshalerForRequest(mux, r)
+		received.Reset()
+		if decodeErr := inbound.NewDecoder(r.Body).Decode(&received); decodeErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	for _, size := range []int{10, 5000} {
+		content := string(bytes.Repeat([]byte("file=1234\n"), size/10))
+		body, contentType := multipartBody(t, map[string]string{"type": "text/plain"}, "file", "text/plain", content)
+		req := httptest.NewRequest(http.MethodPost, "/v2/upload", body)
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %v - %s", rec.Code, rec.Body.String())
+		}
+		if string(received.GetData()) != content || received.GetContentType() != "text/plain" {
+			t.Errorf("Unexpected request message for %d bytes: %v", size, &received)
+		}
+		if wantSpooled := min(size/16, 1); spooled != wantSpooled {
+			t.Errorf("Expected %d spooled file(s) for %d bytes, got %d", wantSpooled, size, spooled)
+		}
+		if entries, _ := os.ReadDir(tmpDir); len(entries) != 0 {
+			t.Errorf("Expected the temporary files to be removed, got %v", entries)
+		}
+	}
+}
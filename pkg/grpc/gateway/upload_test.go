@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+// multipartBody builds a multipart/form-data body with the given form fields and a single file part.
+func multipartBody(t *testing.T, fields map[string]string, fileField, contentType, content string) (*bytes.Buffer, string) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		if err := writer.WriteField(k, v); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(fileField) > 0 {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="`+fileField+`"; filename="scan.wfp"`)
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_, _ = part.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return &buf, writer.FormDataContentType()
+}
+
+func TestGatewayMultipartUploads(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	route := UploadRoute{
+		Path:                "/v2/upload",
+		Message:             &httpbody.HttpBody{},
+		FileFields:          map[string]string{"file": "data"},
+		FormFields:          map[string]string{"type": "content_type"},
+		MaxFileSize:         20,
+		MaxRequestSize:      1024,
+		AllowedContentTypes: []string{"text/*", "application/octet-stream"},
+	}
+	handler, mux := setupTestGateway(t, WithMultipartUploads(route))
+	var received httpbody.HttpBody
+	handleTestPath(t, mux, http.MethodPost, "/v2/upload", func(w http.ResponseWriter, r *http.Request) {
+		inbound, _ := runtime.MarshalerForRequest(mux, r)
+		received.Reset()
+		if decodeErr := inbound.NewDecoder(r.Body).Decode(&received); decodeErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name        string
+		fields      map[string]string
+		fileField   string
+		contentType string
+		content     string
+		status      int
+	}{
+		{name: "upload", fields: map[string]string{"type": "text/plain"}, fileField: "file", contentType: "text/plain", content: "file=1234", status: http.StatusOK},
+		{name: "proto field name", fields: map[string]string{"content_type": "text/plain"}, fileField: "data", contentType: "text/plain", content: "file=1234",
+			status: http.StatusOK},
+		{name: "too large", fileField: "file", contentType: "text/plain", content: strings.Repeat("a", 21), status: http.StatusRequestEntityTooLarge},
+		{name: "request too large", fileField: "file", contentType: "text/plain", content: strings.Repeat("a", 2048), status: http.StatusRequestEntityTooLarge},
+		{name: "content type", fileField: "file", contentType: "image/png", content: "png", status: http.StatusUnsupportedMediaType},
+		{name: "unknown field", fields: map[string]string{"other": "value"}, status: http.StatusBadRequest},
+		{name: "unknown file", fileField: "other", contentType: "text/plain", content: "file=1234", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartBody(t, tt.fields, tt.fileField, tt.contentType, tt.content)
+			req := httptest.NewRequest(http.MethodPost, "/v2/upload", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v - %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status == http.StatusOK && (string(received.GetData()) != tt.content || received.GetContentType() != "text/plain") {
+				t.Errorf("Unexpected request message: %v", &received)
+			}
+		})
+	}
+}
+
+func TestParseUploadValue(t *testing.T) {
+	desc := (&typepb.Field{}).ProtoReflect().Descriptor()
+	tests := []struct {
+		field string
+		value string
+		fail  bool
+	}{
+		{field: "name", value: "purl"},
+		{field: "number", value: "12"},
+		{field: "number", value: "abc", fail: true},
+		{field: "packed", value: "true"},
+		{field: "packed", value: "yes please", fail: true},
+		{field: "kind", value: "TYPE_STRING"},
+		{field: "kind", value: "9"},
+	}
+	for _, tt := range tests {
+		_, err := parseUploadValue(desc.Fields().ByName(protoreflect.Name(tt.field)), []byte(tt.value))
+		if (err != nil) != tt.fail {
+			t.Errorf("Unexpected result for %v=%v: %v", tt.field, tt.value, err)
+		}
+	}
+	if !allowedContentType(nil, "anything") || allowedContentType([]string{"text/*"}, "image/png") ||
+		!allowedContentType([]string{"application/octet-stream"}, "") {
+		t.Errorf("Unexpected content type check")
+	}
+}
+
+func TestGatewayMultipartUploadLimits(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	handler, mux := setupTestGateway(t, WithMultipartUploads(
+		UploadRoute{Path: "/v2/upload", Message: &httpbody.HttpBody{}, FileFields: map[string]string{"file": "data"}, MaxFileSize: 20},
+		UploadRoute{Path: "/v2/upload/default", Message: &httpbody.HttpBody{}, FileFields: map[string]string{"file": "data"}},
+	))
+	for _, path := range []string{"/v2/upload", "/v2/upload/default"} {
+		handleTestPath(t, mux, http.MethodPost, path, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		status   int
+		contains string
+	}{
+		{name: "file within limit", path: "/v2/upload", content: "file=1234", status: http.StatusOK},
+		{name: "file too large", path: "/v2/upload", content: strings.Repeat("a", 21), status: http.StatusRequestEntityTooLarge,
+			contains: "exceeds the maximum size of 20 bytes"},
+		{name: "default request limit", path: "/v2/upload/default", content: strings.Repeat("a", defaultUploadRequestSize+1),
+			status: http.StatusRequestEntityTooLarge, contains: "request exceeds the maximum size of 33554432 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartBody(t, nil, "file", "text/plain", tt.content)
+			req := httptest.NewRequest(http.MethodPost, tt.path, body)
+			req.ContentLength = -1 // unknown length, so the limits must be enforced while reading
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %v, got %v - %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("Expected response to contain %q, got %s", tt.contains, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGatewayMultipartUploadValidation(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	_, _, _, _, err = SetupGateway("9443", "8443", "", "", nil, nil, false, false, false,
+		WithMultipartUploads(UploadRoute{Path: "/v2/upload", FileFields: map[string]string{"file": "data"}}))
+	if err == nil {
+		t.Errorf("Expected an error for an upload route without a request message")
+	}
+	_, _, _, _, err = SetupGateway("9443", "8443", "", "", nil, nil, false, false, false,
+		WithMultipartUploads(UploadRoute{Path: "/v2/upload", Message: (*httpbody.HttpBody)(nil)}))
+	if err != nil {
+		t.Errorf("Unexpected error for a typed nil request message: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+const roundRobinServiceConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+
+var upstreamResolverID atomic.Uint64 // used to give each static upstream resolver a unique scheme
+
+// WithUpstreamHost makes the gateway dial the host given in grpcPort (i.e. "grpc.example.com:50051")
+// instead of forcing the connection to localhost. This allows the gateway to run as a separate deployment.
+func WithUpstreamHost() GatewayOption {
+	return func(cfg *gatewayConfig) {
+		cfg.keepHost = true
+	}
+}
+
+// Upstream describes a gRPC backend the gateway forwards requests to.
+// Each registered service can be given its own upstream (see RegisterUpstreamHandlers).
+type Upstream struct {
+	Name           string   // Name of the upstream/service (used for logging)
+	Addresses      []string // One or more host:port addresses. Multiple addresses are load balanced round-robin
+	TLS            bool     // Connect using TLS
+	CACertFile     string   // Optional CA/server certificate used to verify the upstream (default: system roots)
+	ClientCertFile string   // Optional client certificate for mutual TLS
+	ClientKeyFile  string   // Optional client key for mutual TLS
+	ServerName     string   // Optional name to verify the upstream certificate against (default: the Authority, or each address host)
+	Authority      string   // Optional :authority header override
+}
+
+// DialTarget returns the target & dial options to reach this upstream,
+// suitable for passing to the generated Register...HandlerFromEndpoint functions.
+// Set telemetry if the gateway has WithTelemetry enabled, to trace the upstream calls & propagate the trace context.
+func (u *Upstream) DialTarget(telemetry bool) (string, []grpc.DialOption, error) {
+	if len(u.Addresses) == 0 {
+		return "", nil, fmt.Errorf("no addresses specified for upstream %s", u.Name)
+	}
+	creds, err := u.transportCredentials()
+	if err != nil {
+		zlog.S.Errorf("Problem loading TLS credentials for upstream %s: %v", u.Name, err)
+		return "", nil, fmt.Errorf("failed to load TLS credentials for upstream %s: %v", u.Name, err)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if telemetry {
+		opts = append(opts, telemetryDialOptions()...)
+	}
+	if len(u.Authority) > 0 {
+		opts = append(opts, grpc.WithAuthority(u.Authority))
+	}
+	if len(u.Addresses) == 1 {
+		zlog.S.Debugf("Upstream %s configured for %s", u.Name, u.Addresses[0])
+		return u.Addresses[0], opts, nil
+	}
+	// Multiple static addresses: resolve them locally and balance round-robin across them
+	scheme := fmt.Sprintf("upstream-%d", upstreamResolverID.Add(1))
+	r := manual.NewBuilderWithScheme(scheme)
+	addresses := make([]resolver.Address, 0, len(u.Addresses))
+	for _, addr := range u.Addresses {
+		address := resolver.Address{Addr: addr}
+		if u.TLS && len(u.ServerName) == 0 && len(u.Authority) == 0 {
+			// Verify each server against its own host, rather than the (upstream name) target authority
+			if host, _, splitErr := net.SplitHostPort(addr); splitErr == nil {
+				address.ServerName = host
+			}
+		}
+		addresses = append(addresses, address)
+	}
+	r.InitialState(resolver.State{Addresses: addresses})
+	opts = append(opts, grpc.WithResolvers(r), grpc.WithDefaultServiceConfig(roundRobinServiceConfig))
+	zlog.S.Debugf("Upstream %s configured for round-robin across %v", u.Name, u.Addresses)
+	return scheme + ":///" + strings.ToLower(u.Name), opts, nil
+}
+
+// transportCredentials builds the (m)TLS or insecure credentials for the upstream.
+func (u *Upstream) transportCredentials() (credentials.TransportCredentials, error) {
+	if !u.TLS {
+		return insecure.NewCredentials(), nil
+	}
+	config := &tls.Config{ServerName: u.ServerName, MinVersion: tls.VersionTLS12}
+	if len(u.CACertFile) > 0 {
+		pem, err := os.ReadFile(u.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + u.CACertFile)
+		}
+		config.RootCAs = pool
+	}
+	if len(u.ClientCertFile) > 0 || len(u.ClientKeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(u.ClientCertFile, u.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// upstreamAddress returns the address the gateway should dial for the given gRPC port/address.
+func upstreamAddress(grpcPort string, keepHost bool) string {
+	if !strings.Contains(grpcPort, ":") {
+		return "localhost:" + grpcPort
+	}
+	idx := strings.LastIndex(grpcPort, ":")
+	if keepHost && idx > 0 { // gRPC port has a hostname in it, which should be kept
+		return grpcPort
+	}
+	return "localhost:" + grpcPort[idx+1:]
+}
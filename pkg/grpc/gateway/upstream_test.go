@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestUpstreamAddress(t *testing.T) {
+	tests := []struct {
+		grpcPort string
+		keepHost bool
+		expected string
+	}{
+		{grpcPort: "50051", expected: "localhost:50051"},
+		{grpcPort: ":50051", expected: "localhost:50051"},
+		{grpcPort: "grpc.example.com:50051", expected: "localhost:50051"},
+		{grpcPort: "50051", keepHost: true, expected: "localhost:50051"},
+		{grpcPort: ":50051", keepHost: true, expected: "localhost:50051"},
+		{grpcPort: "grpc.example.com:50051", keepHost: true, expected: "grpc.example.com:50051"},
+	}
+	for _, tt := range tests {
+		if addr := upstreamAddress(tt.grpcPort, tt.keepHost); addr != tt.expected {
+			t.Errorf("Expected %v for %v (keep host: %v), got %v", tt.expected, tt.grpcPort, tt.keepHost, addr)
+		}
+	}
+}
+
+func TestGatewaySetupUpstreamHost(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	_, _, gateway, _, err := SetupGateway("grpc.example.com:9443", "8443", "", "", nil, nil, false, false, false, WithUpstreamHost())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gateway != "grpc.example.com:9443" {
+		t.Errorf("Expected the upstream host to be kept, got %v", gateway)
+	}
+}
+
+func TestUpstreamDialTarget(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name     string
+		upstream Upstream
+		target   string
+		fail     bool
+	}{
+		{name: "no addresses", upstream: Upstream{Name: "empty"}, fail: true},
+		{name: "single", upstream: Upstream{Name: "single", Addresses: []string{"grpc.example.com:443"}, Authority: "api.example.com"},
+			target: "grpc.example.com:443"},
+		{name: "tls", upstream: Upstream{Name: "tls", Addresses: []string{"grpc.example.com:443"}, TLS: true,
+			CACertFile: "../../../tests/server.crt", ServerName: "api.example.com"}, target: "grpc.example.com:443"},
+		{name: "mtls", upstream: Upstream{Name: "mtls", Addresses: []string{"grpc.example.com:443"}, TLS: true,
+			ClientCertFile: "../../../tests/server.crt", ClientKeyFile: "../../../tests/server.key"}, target: "grpc.example.com:443"},
+		{name: "bad ca", upstream: Upstream{Name: "bad", Addresses: []string{"grpc.example.com:443"}, TLS: true,
+			CACertFile: "../../../tests/empty-file.txt"}, fail: true},
+		{name: "bad client cert", upstream: Upstream{Name: "bad", Addresses: []string{"grpc.example.com:443"}, TLS: true,
+			ClientCertFile: "../../../tests/server.crt"}, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, opts, err := tt.upstream.DialTarget(false)
+			if (err != nil) != tt.fail {
+				t.Fatalf("Unexpected error result: %v", err)
+			}
+			if !tt.fail && (target != tt.target || len(opts) == 0) {
+				t.Errorf("Unexpected dial target: %v - %v", target, opts)
+			}
+			if tt.fail {
+				return
+			}
+			_, telemetryOpts, err := tt.upstream.DialTarget(true)
+			if err != nil || len(telemetryOpts) != len(opts)+len(telemetryDialOptions()) {
+				t.Errorf("Expected telemetry dial options to be added: %v - %v", err, telemetryOpts)
+			}
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate & key, valid for the given host, to temporary files.
+func writeTestCertificate(t *testing.T, host string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestUpstreamRoundRobin(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	certFile, keyFile := writeTestCertificate(t, "localhost")
+	serverCreds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name     string
+		upstream Upstream
+		opts     []grpc.ServerOption
+	}{
+		{name: "insecure", upstream: Upstream{Name: "Health"}},
+		{name: "tls", upstream: Upstream{Name: "Health", TLS: true, CACertFile: certFile},
+			opts: []grpc.ServerOption{grpc.Creds(serverCreds)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var counts [2]atomic.Int32
+			for i := range counts {
+				listen, listenErr := net.Listen("tcp", "127.0.0.1:0")
+				if listenErr != nil {
+					t.Fatalf("Unexpected error: %v", listenErr)
+				}
+				server := grpc.NewServer(append(tt.opts, grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo,
+					handler grpc.UnaryHandler) (any, error) {
+					counts[i].Add(1)
+					return handler(ctx, req)
+				}))...)
+				grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+				go func() {
+					_ = server.Serve(listen)
+				}()
+				defer server.Stop()
+				// The certificate is valid for localhost, not the upstream name
+				tt.upstream.Addresses = append(tt.upstream.Addresses, fmt.Sprintf("localhost:%d", listen.Addr().(*net.TCPAddr).Port))
+			}
+			target, opts, err := tt.upstream.DialTarget(false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			conn, err := grpc.NewClient(target, opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer func() {
+				_ = conn.Close()
+			}()
+			client := grpc_health_v1.NewHealthClient(conn)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			for range 10 {
+				if _, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true)); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if counts[0].Load() == 0 || counts[1].Load() == 0 {
+				t.Errorf("Expected requests to be balanced across upstreams, got %v & %v", counts[0].Load(), counts[1].Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/fieldmask"
+)
+
+// fieldMaskPaths returns the partial response field paths requested in the incoming metadata (if any).
+func fieldMaskPaths(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for _, mask := range md.Get(fieldmask.MetadataKey) {
+		paths = append(paths, fieldmask.ParsePaths(mask)...)
+	}
+	return paths
+}
+
+// FieldMaskInterceptor prunes the response down to the field paths requested in the "x-field-mask" metadata.
+// The response "status" field is always returned. The handler's response is left untouched and a pruned copy returned.
+func FieldMaskInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if paths := fieldMaskPaths(ctx); len(paths) > 0 {
+			if msg, ok := resp.(proto.Message); ok && msg.ProtoReflect().IsValid() {
+				pruned := proto.Clone(msg) // don't modify a response the handler may share or cache
+				fieldmask.Prune(pruned, paths)
+				resp = pruned
+			}
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFieldMaskInterceptor(t *testing.T) {
+	handlerErr := errors.New("lookup failed")
+	tests := []struct {
+		name        string
+		md          metadata.MD
+		handlerErr  error
+		wantName    string
+		wantPackage string
+	}{
+		{name: "no metadata", wantName: "scanoss.proto", wantPackage: "scanoss.api"},
+		{name: "no mask", md: metadata.Pairs("x-other", "1"), wantName: "scanoss.proto", wantPackage: "scanoss.api"},
+		{name: "mask", md: metadata.Pairs("x-field-mask", "name"), wantName: "scanoss.proto"},
+		{name: "multiple masks", md: metadata.Pairs("x-field-mask", "name", "x-field-mask", "package"),
+			wantName: "scanoss.proto", wantPackage: "scanoss.api"},
+		{name: "handler error", md: metadata.Pairs("x-field-mask", "package"), handlerErr: handlerErr, wantPackage: "scanoss.api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &descriptorpb.FileDescriptorProto{Name: proto.String("scanoss.proto"), Package: proto.String("scanoss.api")}, tt.handlerErr
+			}
+			resp, err := FieldMaskInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			if !errors.Is(err, tt.handlerErr) {
+				t.Errorf("expected error %v, got %v", tt.handlerErr, err)
+			}
+			file, ok := resp.(*descriptorpb.FileDescriptorProto)
+			if !ok {
+				t.Fatalf("expected a FileDescriptorProto response, got %T", resp)
+			}
+			if file.GetName() != tt.wantName {
+				t.Errorf("expected name %q, got %q", tt.wantName, file.GetName())
+			}
+			if file.GetPackage() != tt.wantPackage {
+				t.Errorf("expected package %q, got %q", tt.wantPackage, file.GetPackage())
+			}
+		})
+	}
+	// A shared (i.e. cached) handler response should not be modified
+	shared := &descriptorpb.FileDescriptorProto{Name: proto.String("scanoss.proto"), Package: proto.String("scanoss.api")}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-field-mask", "name"))
+	resp, err := FieldMaskInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return shared, nil
+	})
+	if err != nil || resp.(*descriptorpb.FileDescriptorProto).GetPackage() != "" {
+		t.Errorf("expected a pruned response & no error, got %v, %v", resp, err)
+	}
+	if shared.GetName() != "scanoss.proto" || shared.GetPackage() != "scanoss.api" {
+		t.Errorf("expected the shared response to be untouched, got %v", shared)
+	}
+	// A nil typed response should be left untouched
+	resp, err = FieldMaskInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return (*descriptorpb.FileDescriptorProto)(nil), nil
+	})
+	if err != nil || resp.(*descriptorpb.FileDescriptorProto) != nil {
+		t.Errorf("expected a nil response & no error, got %v, %v", resp, err)
+	}
+}
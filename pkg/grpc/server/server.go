@@ -66,6 +66,7 @@ func SetupGrpcServer(port, tlsCertFile, tlsKeyFile string, allowedIPs, deniedIPs
 	}
 	interceptors = append(interceptors, grpczap.UnaryServerInterceptor(zlog.L))
 	interceptors = append(interceptors, interceptor.ContextPropagationUnaryServerInterceptor()) // Needs to be called after UnaryServerInterceptor to make sure the logger is set
+	interceptors = append(interceptors, localinterceptor.FieldMaskInterceptor())
 	interceptors = append(interceptors, localinterceptor.ResponseInterceptor())
 
 	var opts []grpc.ServerOption